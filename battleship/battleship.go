@@ -0,0 +1,418 @@
+package battleship
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Size of the board, height is also same
+	Size = 10
+)
+
+const (
+	GameOverState State = 1 << iota
+	WinState
+	TurnState
+	PlaceState
+)
+
+const (
+	UnkownPlayer Player = iota
+	FirstPlayer
+	SecondPlayer
+)
+
+// Cell markers used in the board string, the ship cells are marked with the
+// ship symbol, upper case when intact and lower case when hit
+const (
+	Water = '.'
+	Miss  = 'o'
+)
+
+type Player uint8
+
+type State int
+
+func (s State) String() string {
+	var state string
+
+	switch s {
+	case GameOverState:
+		state = "GameOver"
+	case WinState:
+		state = "Win"
+	case TurnState:
+		state = "Turn"
+	case PlaceState:
+		state = "Place"
+	default:
+		state = "GameOver"
+	}
+
+	return state
+}
+
+func GetState(s string) State {
+	var state State
+
+	switch s {
+	case "GameOver":
+		state = GameOverState
+	case "Win":
+		state = WinState
+	case "Turn":
+		state = TurnState
+	case "Place":
+		state = PlaceState
+	default:
+		state = GameOverState
+	}
+	return state
+}
+
+// Ship describes the ship type in fleet
+type Ship struct {
+	Name   string
+	Symbol byte
+	Length int
+}
+
+// Fleet is the list of ships each player has to place, placement string
+// follows the same order
+var Fleet = []Ship{
+	Ship{"Carrier", 'A', 5},
+	Ship{"Battleship", 'B', 4},
+	Ship{"Cruiser", 'C', 3},
+	Ship{"Submarine", 'S', 3},
+	Ship{"Destroyer", 'D', 2},
+}
+
+// GetShip returns the fleet ship by symbol, case does not matter
+func GetShip(symbol byte) (Ship, bool) {
+	upper := strings.ToUpper(string(symbol))[0]
+
+	for _, ship := range Fleet {
+		if ship.Symbol == upper {
+			return ship, true
+		}
+	}
+	return Ship{}, false
+}
+
+// Spot is a board coordinate, X is the column (A-J) and Y the row (1-10)
+type Spot struct {
+	X, Y uint8
+}
+
+func (s Spot) String() string {
+	return fmt.Sprintf("%c%d", 'A'+s.X, s.Y+1)
+}
+
+// ParseSpot converts the board notation like "C7" into the spot
+func ParseSpot(value string) (Spot, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if len(value) < 2 {
+		return Spot{}, fmt.Errorf("Invalid spot '%s'", value)
+	}
+
+	column := value[0]
+	if column < 'A' || column >= 'A'+Size {
+		return Spot{}, fmt.Errorf("Invalid column in spot '%s'", value)
+	}
+
+	row, err := strconv.Atoi(value[1:])
+	if err != nil || row < 1 || row > Size {
+		return Spot{}, fmt.Errorf("Invalid row in spot '%s'", value)
+	}
+
+	return Spot{uint8(column - 'A'), uint8(row - 1)}, nil
+}
+
+// Board holds the player ships and the shots made by the opponent
+type Board struct {
+	Field [Size][Size]byte
+}
+
+// NewBoard creates a board filled with water
+func NewBoard() Board {
+	board := Board{}
+	Loop(func(x, y uint8) {
+		board.Field[x][y] = Water
+	})
+	return board
+}
+
+// ParseBoard converts the board string back to board, empty string is
+// considered as a board without any ships
+func ParseBoard(value string) (Board, error) {
+	board := NewBoard()
+	if value == "" {
+		return board, nil
+	}
+
+	if len(value) != Size*Size {
+		return board, fmt.Errorf("Invalid board length %d", len(value))
+	}
+
+	Loop(func(x, y uint8) {
+		board.Field[x][y] = value[int(y)*Size+int(x)]
+	})
+	return board, nil
+}
+
+func (b Board) String() string {
+	cells := make([]byte, 0, Size*Size)
+
+	Loop(func(x, y uint8) {
+		cells = append(cells, b.Field[x][y])
+	})
+	return string(cells)
+}
+
+// IsShip checks if there is a ship part at the spot, hit or not
+func (b *Board) IsShip(x, y uint8) bool {
+	_, found := GetShip(b.Field[x][y])
+	return found
+}
+
+// IsHit checks if the ship part at the spot has been hit
+func (b *Board) IsHit(x, y uint8) bool {
+	cell := b.Field[x][y]
+	return b.IsShip(x, y) && cell >= 'a' && cell <= 'z'
+}
+
+// HasShips is true when the fleet has been placed on board
+func (b *Board) HasShips() bool {
+	found := false
+	Loop(func(x, y uint8) {
+		if b.IsShip(x, y) {
+			found = true
+		}
+	})
+	return found
+}
+
+// AllSunk checks if every ship part on board has been hit
+func (b *Board) AllSunk() bool {
+	sunk := true
+	Loop(func(x, y uint8) {
+		if b.IsShip(x, y) && !b.IsHit(x, y) {
+			sunk = false
+		}
+	})
+	return sunk && b.HasShips()
+}
+
+// IsSunk checks if all the parts of the ship have been hit
+func (b *Board) IsSunk(ship Ship) bool {
+	sunk := true
+	Loop(func(x, y uint8) {
+		if b.Field[x][y] == ship.Symbol {
+			sunk = false
+		}
+	})
+	return sunk
+}
+
+// PlaceShip puts the ship on board starting from the spot, going right or
+// down when vertical is set
+func (b *Board) PlaceShip(ship Ship, at Spot, vertical bool) error {
+	spots := make([]Spot, 0, ship.Length)
+
+	for i := 0; i < ship.Length; i++ {
+		spot := Spot{at.X + uint8(i), at.Y}
+		if vertical {
+			spot = Spot{at.X, at.Y + uint8(i)}
+		}
+
+		if spot.X >= Size || spot.Y >= Size {
+			return fmt.Errorf("%s does not fit on board at %s", ship.Name, at)
+		}
+
+		if b.Field[spot.X][spot.Y] != Water {
+			return fmt.Errorf("%s overlaps with other ship at %s", ship.Name, spot)
+		}
+		spots = append(spots, spot)
+	}
+
+	for _, spot := range spots {
+		b.Field[spot.X][spot.Y] = ship.Symbol
+	}
+	return nil
+}
+
+// Fire makes a shot on board, returns true on hit and the ship if the shot
+// sunk it
+func (b *Board) Fire(at Spot) (hit bool, sunk *Ship, err error) {
+	cell := b.Field[at.X][at.Y]
+
+	if cell == Miss || b.IsHit(at.X, at.Y) {
+		return false, nil, fmt.Errorf("Already fired at %s", at)
+	}
+
+	if !b.IsShip(at.X, at.Y) {
+		b.Field[at.X][at.Y] = Miss
+		return false, nil, nil
+	}
+
+	b.Field[at.X][at.Y] = strings.ToLower(string(cell))[0]
+
+	ship, _ := GetShip(cell)
+	if b.IsSunk(ship) {
+		return true, &ship, nil
+	}
+	return true, nil, nil
+}
+
+// View returns the board as seen by the opponent, only hits and misses
+func (b Board) View() Board {
+	view := NewBoard()
+	Loop(func(x, y uint8) {
+		if b.Field[x][y] == Miss || b.IsHit(x, y) {
+			view.Field[x][y] = b.Field[x][y]
+		}
+	})
+	return view
+}
+
+// ParsePlacement creates the board from placement string, each ship in the
+// fleet order is given as start spot with direction, r for right and d for
+// down. Example: "A1r C3d E5r G7d I1d"
+func ParsePlacement(text string) (Board, error) {
+	board := NewBoard()
+	parts := strings.Fields(text)
+
+	if len(parts) != len(Fleet) {
+		return board, fmt.Errorf("Expected %d ships, got %d", len(Fleet), len(parts))
+	}
+
+	for i, part := range parts {
+		part = strings.ToLower(part)
+		direction := part[len(part)-1]
+		if direction != 'r' && direction != 'd' {
+			return board, fmt.Errorf("Missing direction (r or d) for %s", Fleet[i].Name)
+		}
+
+		spot, err := ParseSpot(part[:len(part)-1])
+		if err != nil {
+			return board, err
+		}
+
+		if err = board.PlaceShip(Fleet[i], spot, direction == 'd'); err != nil {
+			return board, err
+		}
+	}
+	return board, nil
+}
+
+// RandomPlacement places the fleet randomly on board with the random source
+func RandomPlacement(r *rand.Rand) Board {
+	board := NewBoard()
+
+	for _, ship := range Fleet {
+		for {
+			spot := Spot{uint8(r.Intn(Size)), uint8(r.Intn(Size))}
+			if board.PlaceShip(ship, spot, r.Intn(2) == 0) == nil {
+				break
+			}
+		}
+	}
+	return board
+}
+
+// Battleship is the game between two players, each board holds the player
+// own ships and the shots made by the opponent
+type Battleship struct {
+	First  Board
+	Second Board
+	Turn   Player
+	State
+}
+
+// Shot is the result of firing
+type Shot struct {
+	Spot
+	Hit  bool
+	Sunk *Ship
+}
+
+// OwnBoard returns the board of player
+func (g *Battleship) OwnBoard(player Player) *Board {
+	if player == SecondPlayer {
+		return &g.Second
+	}
+	return &g.First
+}
+
+// TargetBoard returns the opponent board of player
+func (g *Battleship) TargetBoard(player Player) *Board {
+	if player == SecondPlayer {
+		return &g.First
+	}
+	return &g.Second
+}
+
+// Place sets the player fleet, when both of the players have placed their
+// ships the game turns are starting
+func (g *Battleship) Place(player Player, board Board) error {
+	if g.State != PlaceState {
+		return errors.New("Ships could be placed only before the game starts")
+	}
+
+	*g.OwnBoard(player) = board
+
+	if g.First.HasShips() && g.Second.HasShips() {
+		g.State = TurnState
+	}
+	return nil
+}
+
+// Fire makes the shot for the current turn player and switches the turn
+func (g *Battleship) Fire(at Spot) (Shot, error) {
+	if g.State != TurnState {
+		return Shot{}, errors.New("Game is not in turn state")
+	}
+
+	if at.X >= Size || at.Y >= Size {
+		return Shot{}, fmt.Errorf("Spot %s is out of board", at)
+	}
+
+	target := g.TargetBoard(g.Turn)
+	hit, sunk, err := target.Fire(at)
+	if err != nil {
+		return Shot{}, err
+	}
+
+	if target.AllSunk() {
+		g.State = WinState
+	} else {
+		g.ToggleTurn()
+	}
+
+	return Shot{at, hit, sunk}, nil
+}
+
+func (g *Battleship) ToggleTurn() Player {
+	if g.Turn == FirstPlayer {
+		g.Turn = SecondPlayer
+	} else {
+		g.Turn = FirstPlayer
+	}
+
+	return g.Turn
+}
+
+// Loop helper function to go through all the board cells
+func Loop(fn func(uint8, uint8)) {
+	var x, y uint8
+
+	for y = 0; y < Size; y++ {
+		for x = 0; x < Size; x++ {
+			fn(x, y)
+		}
+	}
+}
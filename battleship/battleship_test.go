@@ -0,0 +1,90 @@
+package battleship
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestParsePlacement(t *testing.T) {
+	board, err := ParsePlacement("A1r A2r A3r A4r A5d")
+	if err != nil {
+		t.Fatal("Placement should be valid", err)
+	}
+
+	if board.Field[4][0] != 'A' || board.Field[0][5] != 'D' {
+		t.Error("Ships are not placed at expected spots", board)
+	}
+
+	if _, err = ParsePlacement("A1r A1d A3r A4r A5d"); err == nil {
+		t.Error("Overlapping ships should not be allowed")
+	}
+
+	if _, err = ParsePlacement("J1r A2r A3r A4r A5d"); err == nil {
+		t.Error("Ship out of board should not be allowed")
+	}
+}
+
+func TestFireUntilWin(t *testing.T) {
+	first, _ := ParsePlacement("A1r A2r A3r A4r A5r")
+	second, _ := ParsePlacement("A1r A2r A3r A4r A5r")
+
+	game := &Battleship{Turn: FirstPlayer, State: PlaceState}
+	game.Place(FirstPlayer, first)
+	game.Place(SecondPlayer, second)
+
+	if game.State != TurnState {
+		t.Fatal("Game should start after both players have placed ships")
+	}
+
+	lengths := []int{5, 4, 3, 3, 2}
+	for y, length := range lengths {
+		for x := 0; x < length; x++ {
+			shot, err := game.Fire(Spot{uint8(x), uint8(y)})
+			if err != nil || !shot.Hit {
+				t.Fatal("Shot should hit", x, y, err)
+			}
+
+			if game.State == WinState {
+				break
+			}
+
+			// Opponent misses always
+			if _, err = game.Fire(Spot{uint8(x), uint8(5 + y)}); err != nil {
+				t.Fatal("Opponent shot should be valid", err)
+			}
+		}
+	}
+
+	if game.State != WinState || game.Turn != FirstPlayer {
+		t.Error("First player should win the game")
+	}
+
+	if _, err := game.Fire(Spot{0, 0}); err == nil {
+		t.Error("Should not fire after the game is won")
+	}
+}
+
+func TestParseSpot(t *testing.T) {
+	spot, err := ParseSpot("c7")
+	if err != nil || spot.X != 2 || spot.Y != 6 {
+		t.Error("Expected C7 to be parsed", spot, err)
+	}
+
+	for _, value := range []string{"K1", "A0", "A11", "7"} {
+		if _, err := ParseSpot(value); err == nil {
+			t.Error("Spot should be invalid", value)
+		}
+	}
+}
+
+func TestRandomPlacementSeed(t *testing.T) {
+	board := RandomPlacement(rand.New(rand.NewSource(42)))
+
+	if !board.HasShips() {
+		t.Error("All the ships should be placed", board)
+	}
+
+	if board.String() != RandomPlacement(rand.New(rand.NewSource(42))).String() {
+		t.Error("Same seed should give the same placement")
+	}
+}
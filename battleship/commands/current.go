@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/battleship"
+	btsdatastore "github.com/slack-games/slack-server/battleship/datastore"
+	"github.com/slack-games/slack-server/secret"
+)
+
+// CurrentCommand show the current user game state
+func CurrentCommand(db *sqlx.DB, signer *secret.Signer, teamID, userID string) slack.ResponseMessage {
+	log.Println("Show user current game", userID)
+	state, err := btsdatastore.GetUserLastState(db, teamID, userID)
+
+	// No state found
+	if err != nil {
+		return slack.TextOnly("Could not get the current game, but you could `/battleship start @user` a new one")
+	}
+
+	var message string
+	opponentID := state.GetOpponentID(userID)
+
+	switch battleship.GetState(state.Mode) {
+	case battleship.PlaceState:
+		message = fmt.Sprintf("Playing with <@%s>, waiting for the ships placement. %s", opponentID, placeHelp)
+	case battleship.TurnState:
+		message = fmt.Sprintf("Playing with <@%s>, it's now <@%s> turn - _at %s_",
+			opponentID, state.TurnID, state.Created.Format("15:04:05 02-01-06"))
+	default:
+		message = fmt.Sprintf(":tada: Game won by <@%s> - _at %s_. For a new game `/battleship start @user` :tada:",
+			state.TurnID, state.Created.Format("15:04:05 02-01-06"))
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, signer, "Last game state", state.StateID, userID)},
+	}
+}
+
+// Each player gets the image of own fleet and the opponent waters, the image
+// URL is signed for the player so the opponent fleet stays hidden
+func imageAttachment(db *sqlx.DB, signer *secret.Signer, title, stateID, userID string) slack.Attachment {
+	token := ImageToken(signer, stateID, userID)
+
+	return slack.Attachment{
+		Title:    title,
+		Fallback: boardText(db, stateID, userID),
+		ImageURL: fmt.Sprintf("%s/game/battleship/image/%s/%s", os.Getenv("BASE_PATH"), stateID, token),
+		Color:    "#764FA5",
+	}
+}
@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/battleship"
+	btsdatastore "github.com/slack-games/slack-server/battleship/datastore"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/secret"
+)
+
+// FireCommand makes the shot to the opponent board
+func FireCommand(db *sqlx.DB, signer *secret.Signer, teamID, userID string, spot battleship.Spot) slack.ResponseMessage {
+	state, err := btsdatastore.GetUserLastState(db, teamID, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return slack.TextOnly("You can not fire before the game has started `/battleship start @user`")
+		}
+		log.Println("Error could not get the user state", err)
+		return slack.TextOnly("Could not get the last game state")
+	}
+
+	if isGameOver(state) {
+		return slack.TextOnly("Current game is over, but you can always start a new game `/battleship start @user`")
+	}
+
+	if state.Mode == fmt.Sprintf("%s", battleship.PlaceState) {
+		return slack.TextOnly("Both players have to place the ships before firing")
+	}
+
+	if state.TurnID != userID {
+		return slack.TextOnly(fmt.Sprintf("It's not your turn, waiting for <@%s>", state.TurnID))
+	}
+
+	game, err := btsdatastore.CreateBattleshipGame(state)
+	if err != nil {
+		log.Println("Could not create the game from state", err)
+		return slack.TextOnly("Could not read the game state")
+	}
+
+	shot, err := game.Fire(spot)
+	if err != nil {
+		return slack.TextOnly(fmt.Sprintf("Could not fire at %s: %s", spot, err))
+	}
+
+	newState := btsdatastore.CreateStateFromGame(game, state)
+	stateID, err := btsdatastore.NewState(db, *newState)
+	if err == datastore.ErrConflict {
+		return slack.TextOnly("The game changed meanwhile, check it with `/battleship current` and try again")
+	}
+	if err != nil {
+		log.Println("Could not save the new state", err)
+		return slack.TextOnly("Could not save the shot")
+	}
+
+	message := fmt.Sprintf(":ocean: Miss at *[%s]*, now it's <@%s> turn", spot, newState.TurnID)
+	if shot.Hit {
+		message = fmt.Sprintf(":boom: Hit at *[%s]*, now it's <@%s> turn", spot, newState.TurnID)
+	}
+	if shot.Sunk != nil {
+		message = fmt.Sprintf(":boom: You sunk the %s at *[%s]*, now it's <@%s> turn",
+			shot.Sunk.Name, spot, newState.TurnID)
+	}
+	if game.State == battleship.WinState {
+		message = fmt.Sprintf(":tada: You sunk the whole fleet of <@%s>, the game is won :tada:",
+			state.GetOpponentID(userID))
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, signer, "The current game state", stateID, userID)},
+	}
+}
@@ -0,0 +1,52 @@
+package commands
+
+import "github.com/slack-games/slack-client"
+
+const helpText = `
+Challenge a teammate with _/battleship start @user_, both of you place the ships
+privately and then take turns firing at each other waters.
+Fire by typing _/battleship fire spot_ - spot is column A-J with row 1-10, example _/battleship fire C7_.
+Sink the whole opponent fleet to win.
+
+Good luck!
+`
+
+// HelpCommand show the possible info about available commands for user
+func HelpCommand() slack.ResponseMessage {
+
+	attachments := []slack.Attachment{
+		slack.Attachment{
+			Title: "/battleship start @user - challenges teammate to a new game",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/battleship place A1r C3d E5r G7d I1d - place ships, r - right, d - down",
+			Color: "#FF4F20",
+		},
+		slack.Attachment{
+			Title: "/battleship place random - place ships randomly",
+			Color: "#FF4F20",
+		},
+		slack.Attachment{
+			Title: "/battleship fire [A-J][1-10] - fire at opponent waters",
+			Color: "#004FDD",
+		},
+		slack.Attachment{
+			Title: "/battleship current - show the state of current game",
+			Color: "#76A0A0",
+		},
+		slack.Attachment{
+			Title: "/battleship boards [text|image] - show the boards as text or images",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/battleship help - Shows help message",
+			Color: "#76A0A0",
+		},
+	}
+
+	return slack.ResponseMessage{
+		Text:        helpText,
+		Attachments: attachments,
+	}
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"errors"
+	"image"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-server/battleship"
+	btsdatastore "github.com/slack-games/slack-server/battleship/datastore"
+	drawBoard "github.com/slack-games/slack-server/battleship/draw"
+	"github.com/slack-games/slack-server/secret"
+)
+
+// imageMaxAge is how long the signed image URL works, Slack loads the image
+// again when the message is shown after its cache expires
+const imageMaxAge = 90 * 24 * time.Hour
+
+// ImageToken signs the state and the user of the board view, the image URL
+// carries it instead of the plain user ID
+func ImageToken(signer *secret.Signer, stateID, userID string) string {
+	return signer.Sign(stateID+":"+userID, time.Now().Add(imageMaxAge))
+}
+
+// VerifyImageToken returns the user of the board view, the token has to be
+// signed for the state
+func VerifyImageToken(signer *secret.Signer, token, stateID string) (string, error) {
+	value, err := signer.Verify(token, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	if !strings.HasPrefix(value, stateID+":") {
+		return "", secret.ErrInvalidSignature
+	}
+	return strings.TrimPrefix(value, stateID+":"), nil
+}
+
+// GetGameImage returns the image by state from the user view point
+func GetGameImage(db *sqlx.DB, stateID, userID string) (image.Image, error) {
+	state, err := btsdatastore.GetState(db, stateID)
+	if err != nil {
+		return nil, errors.New("Could not get the state")
+	}
+
+	player := state.GetPlayer(userID)
+	if player == battleship.UnkownPlayer {
+		return nil, errors.New("User is not playing in this game")
+	}
+
+	game, err := btsdatastore.CreateBattleshipGame(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return drawBoard.Draw(game, player), nil
+}
+
+// GetGameText returns the board by state from the user view point as text
+func GetGameText(db *sqlx.DB, stateID, userID string) (string, error) {
+	state, err := btsdatastore.GetState(db, stateID)
+	if err != nil {
+		return "", errors.New("Could not get the state")
+	}
+
+	player := state.GetPlayer(userID)
+	if player == battleship.UnkownPlayer {
+		return "", errors.New("User is not playing in this game")
+	}
+
+	game, err := btsdatastore.CreateBattleshipGame(state)
+	if err != nil {
+		return "", err
+	}
+
+	return drawBoard.Text(game, player), nil
+}
+
+// boardText is the text board of the attachment, shown instead of the image
+// when it could not be loaded
+func boardText(db *sqlx.DB, stateID, userID string) string {
+	text, err := GetGameText(db, stateID, userID)
+	if err != nil {
+		log.Println("Could not get the text board", stateID, err)
+		return "The game board"
+	}
+	return text
+}
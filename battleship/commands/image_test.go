@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/slack-games/slack-server/secret"
+)
+
+func TestImageToken(t *testing.T) {
+	signer := secret.NewSigner(secret.DeriveKey([]byte("key"), "images"))
+	stateID := "00000000-0000-0000-0000-000000000001"
+
+	token := ImageToken(signer, stateID, "U000000001")
+	if userID, err := VerifyImageToken(signer, token, stateID); err != nil || userID != "U000000001" {
+		t.Error("Token should give the user of the view", userID, err)
+	}
+
+	if _, err := VerifyImageToken(signer, token, "00000000-0000-0000-0000-000000000002"); err == nil {
+		t.Error("Token of the other state should not be verified")
+	}
+
+	if _, err := VerifyImageToken(signer, "U000000002", stateID); err == nil {
+		t.Error("Plain user ID should not be verified")
+	}
+
+	other := secret.NewSigner(secret.DeriveKey([]byte("key"), "cookies"))
+	if _, err := VerifyImageToken(other, token, stateID); err == nil {
+		t.Error("Token signed with the other key should not be verified")
+	}
+}
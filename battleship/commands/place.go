@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/battleship"
+	btsdatastore "github.com/slack-games/slack-server/battleship/datastore"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/secret"
+)
+
+// PlaceCommand places the user fleet on board, the placement is either
+// "random" or the ships start spots with directions
+func PlaceCommand(db *sqlx.DB, signer *secret.Signer, teamID, userID, placement string) slack.ResponseMessage {
+	state, err := btsdatastore.GetUserLastState(db, teamID, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return slack.TextOnly("You have to start a game first `/battleship start @user`")
+		}
+		log.Println("Error could not get the user state", err)
+		return slack.TextOnly("Could not get the last game state")
+	}
+
+	if state.Mode != fmt.Sprintf("%s", battleship.PlaceState) {
+		return slack.TextOnly("Ships are already placed, make your move `/battleship fire C7`")
+	}
+
+	game, err := btsdatastore.CreateBattleshipGame(state)
+	if err != nil {
+		log.Println("Could not create the game from state", err)
+		return slack.TextOnly("Could not read the game state")
+	}
+
+	var board battleship.Board
+	if strings.TrimSpace(placement) == "random" {
+		// Each player has own step so the placements differ
+		board = battleship.RandomPlacement(datastore.Rand(state.Seed, int(state.GetPlayer(userID))))
+	} else if board, err = battleship.ParsePlacement(placement); err != nil {
+		return slack.TextOnly(fmt.Sprintf("Could not place the ships: %s. %s", err, placeHelp))
+	}
+
+	player := state.GetPlayer(userID)
+	if err = game.Place(player, board); err != nil {
+		return slack.TextOnly(err.Error())
+	}
+
+	newState := btsdatastore.CreateStateFromGame(game, state)
+	stateID, err := btsdatastore.NewState(db, *newState)
+	if err == datastore.ErrConflict {
+		return slack.TextOnly("The game changed meanwhile, check it with `/battleship current` and try again")
+	}
+	if err != nil {
+		log.Println("Could not save the new state", err)
+		return slack.TextOnly("Could not save the ships placement")
+	}
+
+	message := fmt.Sprintf("Your fleet is ready, waiting for <@%s> to place the ships",
+		state.GetOpponentID(userID))
+	if game.State == battleship.TurnState {
+		message = fmt.Sprintf("Both fleets are ready, <@%s> makes the first shot `/battleship fire C7`",
+			newState.TurnID)
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, signer, "Your fleet", stateID, userID)},
+	}
+}
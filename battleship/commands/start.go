@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/battleship"
+	btsdatastore "github.com/slack-games/slack-server/battleship/datastore"
+	"github.com/slack-games/slack-server/datastore"
+)
+
+const placeHelp = "Place your ships with `/battleship place A1r C3d E5r G7d I1d` " +
+	"(carrier 5, battleship 4, cruiser 3, submarine 3, destroyer 2; r - right, d - down) " +
+	"or let the bot do it `/battleship place random`"
+
+// StartCommand challenges the opponent to a new game
+func StartCommand(db *sqlx.DB, teamID, userID, opponentID string) slack.ResponseMessage {
+	if userID == opponentID {
+		return slack.TextOnly("You can not challenge yourself, pick a teammate")
+	}
+
+	for _, id := range []string{userID, opponentID} {
+		state, err := btsdatastore.GetUserLastState(db, teamID, id)
+		if err != nil && err != sql.ErrNoRows {
+			log.Println("Error could not get the user state", err)
+			return slack.TextOnly("Could not get the last game state")
+		}
+
+		if err == nil && !isGameOver(state) {
+			if id == userID {
+				return slack.TextOnly("There's already existing a game, you have to finish it before starting a new `/battleship current`")
+			}
+			return slack.TextOnly("Your opponent is already playing, try again later")
+		}
+	}
+
+	state := btsdatastore.GetNewState(teamID, userID, opponentID, datastore.NewSeed())
+
+	log.Println("Create a new battleship state")
+	stateID, err := btsdatastore.NewState(db, state)
+	if err != nil {
+		log.Fatalln("Could not create a new state", err)
+	}
+	log.Println("New state id", stateID)
+
+	return slack.TextOnly(fmt.Sprintf("Created a new game with <@%s>. %s", opponentID, placeHelp))
+}
+
+func isGameOver(state btsdatastore.State) bool {
+	return state.Mode == fmt.Sprintf("%s", battleship.GameOverState) ||
+		state.Mode == fmt.Sprintf("%s", battleship.WinState) ||
+		state.Mode == datastore.ForfeitMode
+}
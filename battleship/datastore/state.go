@@ -0,0 +1,213 @@
+package datastore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-server/battleship"
+	gmsdatastore "github.com/slack-games/slack-server/datastore"
+)
+
+type State struct {
+	StateID      string    `db:"state_id"`
+	TeamID       string    `db:"team_id"`
+	FirstBoard   string    `db:"first_board"`
+	SecondBoard  string    `db:"second_board"`
+	TurnID       string    `db:"turn"`
+	Mode         string    `db:"mode"`
+	FirstUserID  string    `db:"first_user_id"`
+	SecondUserID string    `db:"second_user_id"`
+	Seed         int64     `db:"seed"`
+	ParentID     string    `db:"parent_state_id"`
+	Created      time.Time `db:"created_at"`
+}
+
+func (s State) String() string {
+	return fmt.Sprintf("#[%s] - %s %s %s %s %s",
+		s.StateID, s.TurnID, s.Mode, s.FirstUserID, s.SecondUserID, s.Created)
+}
+
+// GetNewState creates the placement state for two players, the challenger
+// makes the first shot. The seed is used for the random placements
+func GetNewState(teamID, userID, opponentID string, seed int64) State {
+	return State{
+		TeamID:       teamID,
+		FirstBoard:   "",
+		SecondBoard:  "",
+		TurnID:       userID,
+		Mode:         fmt.Sprintf("%s", battleship.PlaceState),
+		FirstUserID:  userID,
+		SecondUserID: opponentID,
+		Seed:         seed,
+		ParentID:     "00000000-0000-0000-0000-000000000000",
+		Created:      time.Now(),
+	}
+}
+
+// GetPlayer returns the player in game for the user
+func (s State) GetPlayer(userID string) battleship.Player {
+	switch userID {
+	case s.FirstUserID:
+		return battleship.FirstPlayer
+	case s.SecondUserID:
+		return battleship.SecondPlayer
+	}
+	return battleship.UnkownPlayer
+}
+
+// GetOpponentID returns the other player of the game
+func (s State) GetOpponentID(userID string) string {
+	if userID == s.FirstUserID {
+		return s.SecondUserID
+	}
+	return s.FirstUserID
+}
+
+// GetWaitingID returns the user the game is waiting for, while placing the
+// ships it's the first player without the fleet on board
+func (s State) GetWaitingID() string {
+	if s.Mode == fmt.Sprintf("%s", battleship.PlaceState) {
+		if s.FirstBoard == "" {
+			return s.FirstUserID
+		}
+		if s.SecondBoard == "" {
+			return s.SecondUserID
+		}
+	}
+	return s.TurnID
+}
+
+func CreateStateFromGame(game *battleship.Battleship, state State) *State {
+	turnID := state.FirstUserID
+	if game.Turn == battleship.SecondPlayer {
+		turnID = state.SecondUserID
+	}
+
+	return &State{
+		TeamID:       state.TeamID,
+		FirstBoard:   boardToString(game.First),
+		SecondBoard:  boardToString(game.Second),
+		TurnID:       turnID,
+		Mode:         fmt.Sprintf("%s", game.State),
+		FirstUserID:  state.FirstUserID,
+		SecondUserID: state.SecondUserID,
+		Seed:         state.Seed,
+		ParentID:     state.StateID,
+		Created:      time.Now(),
+	}
+}
+
+func CreateBattleshipGame(state State) (*battleship.Battleship, error) {
+	first, err := battleship.ParseBoard(state.FirstBoard)
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := battleship.ParseBoard(state.SecondBoard)
+	if err != nil {
+		return nil, err
+	}
+
+	return &battleship.Battleship{
+		First:  first,
+		Second: second,
+		Turn:   state.GetPlayer(state.TurnID),
+		State:  battleship.GetState(state.Mode),
+	}, nil
+}
+
+// CreateForfeitState ends the game on timeout, the waited player loses and
+// the opponent is kept in turn as the winner
+func CreateForfeitState(state State) *State {
+	return &State{
+		TeamID:       state.TeamID,
+		FirstBoard:   state.FirstBoard,
+		SecondBoard:  state.SecondBoard,
+		TurnID:       state.GetOpponentID(state.GetWaitingID()),
+		Mode:         gmsdatastore.ForfeitMode,
+		FirstUserID:  state.FirstUserID,
+		SecondUserID: state.SecondUserID,
+		Seed:         state.Seed,
+		ParentID:     state.StateID,
+		Created:      time.Now(),
+	}
+}
+
+// Keep the not yet placed boards empty in the DB
+func boardToString(board battleship.Board) string {
+	if !board.HasShips() {
+		return ""
+	}
+	return board.String()
+}
+
+func GetState(db *sqlx.DB, id string) (State, error) {
+	state := State{}
+
+	err := db.Get(&state, `SELECT * FROM bts.states WHERE state_id=$1 LIMIT 1`, id)
+	return state, err
+}
+
+func GetUserLastState(db *sqlx.DB, teamID, userID string) (State, error) {
+	state := State{}
+
+	query := `
+		SELECT *
+		FROM bts.states
+		WHERE
+			team_id=$1 AND (first_user_id=$2 OR second_user_id=$2)
+		ORDER BY created_at DESC LIMIT 1;
+	`
+
+	err := db.Get(&state, query, teamID, userID)
+	return state, err
+}
+
+// GetStaleStates returns the running games not continued since before, the
+// game is running while it's the latest one of the players
+func GetStaleStates(db *sqlx.DB, before time.Time) ([]State, error) {
+	states := []State{}
+
+	query := `
+		SELECT s.*
+		FROM bts.states s
+		WHERE
+			s.mode IN ('Place', 'Turn') AND s.created_at < $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM bts.states n
+				WHERE
+					n.team_id = s.team_id AND n.created_at > s.created_at
+					AND (n.first_user_id IN (s.first_user_id, s.second_user_id)
+						OR n.second_user_id IN (s.first_user_id, s.second_user_id))
+			)
+	`
+
+	err := db.Select(&states, query, before)
+	return states, err
+}
+
+// NewState saves the next game state, ErrConflict is returned when the state
+// has been continued already
+func NewState(db *sqlx.DB, state State) (string, error) {
+	sql := `
+		INSERT INTO bts.states
+			(team_id, first_board, second_board, turn, mode, first_user_id, second_user_id, seed, parent_state_id)
+		VALUES
+			(:team_id, :first_board, :second_board, :turn, :mode, :first_user_id, :second_user_id, :seed, :parent_state_id)
+		RETURNING state_id
+	`
+	var id string
+
+	rows, err := db.NamedQuery(sql, state)
+	if err != nil {
+		return id, gmsdatastore.CheckConflict(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		rows.Scan(&id)
+	}
+	return id, gmsdatastore.CheckConflict(rows.Err())
+}
@@ -0,0 +1,27 @@
+package datastore
+
+import "testing"
+
+func TestCreateForfeitStateWhilePlacing(t *testing.T) {
+	state := State{StateID: "s1", FirstBoard: "", SecondBoard: "", TurnID: "U1", Mode: "Place",
+		FirstUserID: "U1", SecondUserID: "U2"}
+
+	// The second player has placed the fleet, the first one is waited
+	state.SecondBoard = "placed"
+	forfeit := CreateForfeitState(state)
+	if forfeit.TurnID != "U2" || forfeit.Mode != "Forfeit" || forfeit.ParentID != "s1" {
+		t.Error("Second player should win the forfeit", forfeit)
+	}
+
+	// The first player has placed the fleet, the second one is waited
+	state.FirstBoard, state.SecondBoard = "placed", ""
+	if forfeit = CreateForfeitState(state); forfeit.TurnID != "U1" {
+		t.Error("First player should win the forfeit", forfeit)
+	}
+
+	// While firing the player in turn is waited
+	state.SecondBoard, state.Mode, state.TurnID = "placed", "Turn", "U2"
+	if forfeit = CreateForfeitState(state); forfeit.TurnID != "U1" {
+		t.Error("Player not in turn should win the forfeit", forfeit)
+	}
+}
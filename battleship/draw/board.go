@@ -0,0 +1,161 @@
+package draw
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"os"
+
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dimg"
+	kit "github.com/llgcode/draw2d/draw2dkit"
+	"github.com/slack-games/slack-server/battleship"
+)
+
+const (
+	Width    = 650
+	Height   = 350
+	Offset   = 25.0
+	CellSize = 28.0
+	// Space between the own and target boards
+	BoardGap = 40.0
+)
+
+var DefaultColor, FirstColor, SecondColor color.RGBA
+var WaterColor, RedColor color.RGBA
+
+func init() {
+	// #444444
+	DefaultColor = color.RGBA{0x44, 0x44, 0x44, 0xff}
+	// #0A63BB
+	FirstColor = color.RGBA{0x0a, 0x63, 0xbb, 0xff}
+	// #6D083E
+	SecondColor = color.RGBA{0x6d, 0x08, 0x3e, 0xff}
+	// #D6EAF8
+	WaterColor = color.RGBA{0xd6, 0xea, 0xf8, 0xff}
+	// #FF0000
+	RedColor = color.RGBA{0xff, 0x0, 0x0, 0xff}
+}
+
+// DrawGrid draws the board grid with column letters and row numbers
+func DrawGrid(gc *draw2dimg.GraphicContext, left, top float64, title string) {
+	size := CellSize * battleship.Size
+
+	gc.Save()
+	gc.SetFillColor(WaterColor)
+	kit.Rectangle(gc, left, top, left+size, top+size)
+	gc.Fill()
+
+	gc.SetStrokeColor(DefaultColor)
+	gc.SetLineWidth(1)
+	for i := 0; i <= battleship.Size; i++ {
+		pos := float64(i) * CellSize
+
+		gc.MoveTo(left+pos, top)
+		gc.LineTo(left+pos, top+size)
+		gc.Stroke()
+
+		gc.MoveTo(left, top+pos)
+		gc.LineTo(left+size, top+pos)
+		gc.Stroke()
+	}
+
+	gc.SetFillColor(color.Black)
+	gc.SetFontSize(10)
+	for i := 0; i < battleship.Size; i++ {
+		pos := float64(i)*CellSize + CellSize/2
+		gc.FillStringAt(fmt.Sprintf("%c", 'A'+i), left+pos-4, top-5)
+		gc.FillStringAt(fmt.Sprintf("%d", i+1), left-18, top+pos+4)
+	}
+
+	gc.SetFontSize(12)
+	gc.FillStringAt(title, left, top+size+20)
+	gc.Restore()
+}
+
+// DrawBoard draws the ships, hits and misses of the board
+func DrawBoard(gc *draw2dimg.GraphicContext, left, top float64, board battleship.Board, shipColor color.RGBA) {
+	battleship.Loop(func(x, y uint8) {
+		xPos := left + float64(x)*CellSize
+		yPos := top + float64(y)*CellSize
+		hCell := CellSize / 2
+
+		gc.Save()
+		if board.IsShip(x, y) {
+			gc.SetFillColor(shipColor)
+			kit.Rectangle(gc, xPos+3, yPos+3, xPos+CellSize-3, yPos+CellSize-3)
+			gc.Fill()
+		}
+
+		if board.IsHit(x, y) {
+			gc.SetStrokeColor(RedColor)
+			gc.SetLineWidth(3)
+			gc.MoveTo(xPos+6, yPos+6)
+			gc.LineTo(xPos+CellSize-6, yPos+CellSize-6)
+			gc.Stroke()
+			gc.MoveTo(xPos+CellSize-6, yPos+6)
+			gc.LineTo(xPos+6, yPos+CellSize-6)
+			gc.Stroke()
+		}
+
+		if board.Field[x][y] == battleship.Miss {
+			gc.SetFillColor(DefaultColor)
+			kit.Circle(gc, xPos+hCell, yPos+hCell, 4)
+			gc.Fill()
+		}
+		gc.Restore()
+	})
+}
+
+// Draw creates the image of the game from the player view point, left side
+// has the player own board and right side the known opponent board
+func Draw(game *battleship.Battleship, player battleship.Player) image.Image {
+
+	// Initialize the graphic context on an RGBA image
+	dest := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	gc := draw2dimg.NewGraphicContext(dest)
+
+	fontPath := os.Getenv("FONT_PATH")
+	if fontPath == "" {
+		log.Fatalln("No FONT_PATH has been set")
+	}
+
+	draw2d.SetFontFolder(fontPath)
+
+	gc.SetFontData(draw2d.FontData{
+		Name:   "luxi",
+		Family: draw2d.FontFamilyMono,
+		Style:  draw2d.FontStyleBold,
+	})
+
+	ownColor, targetColor := FirstColor, SecondColor
+	if player == battleship.SecondPlayer {
+		ownColor, targetColor = SecondColor, FirstColor
+	}
+
+	left := Offset + 10
+	top := Offset
+	targetLeft := left + CellSize*battleship.Size + BoardGap
+
+	DrawGrid(gc, left, top, "Your fleet")
+	DrawBoard(gc, left, top, *game.OwnBoard(player), ownColor)
+
+	DrawGrid(gc, targetLeft, top, "Opponent waters")
+	DrawBoard(gc, targetLeft, top, game.TargetBoard(player).View(), targetColor)
+
+	if game.State == battleship.WinState {
+		message := "You won"
+		if game.Turn != player {
+			message = "You lost"
+		}
+
+		gc.Save()
+		gc.SetFillColor(RedColor)
+		gc.SetFontSize(14)
+		gc.FillStringAt(message, Width/2-30, Height-10)
+		gc.Restore()
+	}
+
+	return dest
+}
@@ -0,0 +1,47 @@
+package draw
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/slack-games/slack-server/battleship"
+)
+
+// Text returns the own fleet and the opponent waters as text grids, for the
+// clients without images. Ships are # when intact and X when hit, misses o
+func Text(game *battleship.Battleship, player battleship.Player) string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("```\n")
+	buffer.WriteString(fmt.Sprintf("%-24s%s\n", "Your fleet", "Opponent waters"))
+
+	header := "   A B C D E F G H I J"
+	buffer.WriteString(fmt.Sprintf("%-24s%s\n", header, header))
+
+	own := game.OwnBoard(player)
+	target := game.TargetBoard(player).View()
+
+	for y := uint8(0); y < battleship.Size; y++ {
+		buffer.WriteString(fmt.Sprintf("%-24s%s\n", textRow(own, y), textRow(&target, y)))
+	}
+	buffer.WriteString("```")
+	return buffer.String()
+}
+
+func textRow(board *battleship.Board, y uint8) string {
+	row := fmt.Sprintf("%2d", y+1)
+
+	for x := uint8(0); x < battleship.Size; x++ {
+		symbol := "."
+		switch {
+		case board.IsHit(x, y):
+			symbol = "X"
+		case board.IsShip(x, y):
+			symbol = "#"
+		case board.Field[x][y] == battleship.Miss:
+			symbol = "o"
+		}
+		row += " " + symbol
+	}
+	return row
+}
@@ -0,0 +1,448 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Size of the board, height is also same
+	Size = 8
+	// Squares is the number of playable dark squares
+	Squares = 32
+	// MaxQuietMoves is the number of moves without captures or man moves
+	// after which the game is a draw, 40 moves by each player
+	MaxQuietMoves = 80
+)
+
+const (
+	GameOverState State = 1 << iota
+	DrawState
+	WinState
+	TurnState
+	StartState
+)
+
+const (
+	UnkownPlayer Player = iota
+	// FirstPlayer plays with black pieces from squares 1-12 and moves first
+	FirstPlayer
+	// SecondPlayer plays with white pieces from squares 21-32
+	SecondPlayer
+)
+
+// Pieces on the board squares
+const (
+	Empty Piece = iota
+	FirstMan
+	SecondMan
+	FirstKing
+	SecondKing
+)
+
+var moveRegexp = regexp.MustCompile("^\\d{1,2}([-x]\\d{1,2})+$")
+
+type Player uint8
+
+type Piece uint8
+
+type State int
+
+func (s State) String() string {
+	var state string
+
+	switch s {
+	case GameOverState:
+		state = "GameOver"
+
+	case WinState:
+		state = "Win"
+
+	case DrawState:
+		state = "Draw"
+
+	case TurnState:
+		state = "Turn"
+
+	case StartState:
+		state = "Start"
+
+	default:
+		state = "Unkown"
+	}
+
+	return state
+}
+
+func GetState(s string) State {
+	var state State
+
+	switch s {
+	case "GameOver":
+		state = GameOverState
+	case "Win":
+		state = WinState
+	case "Draw":
+		state = DrawState
+	case "Turn":
+		state = TurnState
+	case "Start":
+		state = StartState
+	default:
+		state = GameOverState
+	}
+	return state
+}
+
+// Opponent returns the other player
+func (p Player) Opponent() Player {
+	if p == FirstPlayer {
+		return SecondPlayer
+	}
+	return FirstPlayer
+}
+
+// Owner returns the player of the piece
+func (p Piece) Owner() Player {
+	switch p {
+	case FirstMan, FirstKing:
+		return FirstPlayer
+	case SecondMan, SecondKing:
+		return SecondPlayer
+	}
+	return UnkownPlayer
+}
+
+func (p Piece) IsKing() bool {
+	return p == FirstKing || p == SecondKing
+}
+
+// Crown turns the man into king
+func (p Piece) Crown() Piece {
+	switch p {
+	case FirstMan:
+		return FirstKing
+	case SecondMan:
+		return SecondKing
+	}
+	return p
+}
+
+// Move is the path of squares (1-32) the piece takes, captures holds the
+// jumped over squares
+type Move struct {
+	Path     []int
+	Captures []int
+}
+
+func (m Move) IsJump() bool {
+	return len(m.Captures) > 0
+}
+
+func (m Move) String() string {
+	separator := "-"
+	if m.IsJump() {
+		separator = "x"
+	}
+
+	squares := make([]string, len(m.Path))
+	for i, square := range m.Path {
+		squares[i] = strconv.Itoa(square)
+	}
+	return strings.Join(squares, separator)
+}
+
+// ParseMove converts notation like "11-15" or "11x18x25" into the squares
+func ParseMove(value string) ([]int, error) {
+	value = strings.TrimSpace(value)
+	if !moveRegexp.MatchString(value) {
+		return nil, fmt.Errorf("Invalid move notation '%s'", value)
+	}
+
+	var squares []int
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == '-' || r == 'x' }) {
+		square, _ := strconv.Atoi(part)
+		if square < 1 || square > Squares {
+			return nil, fmt.Errorf("Invalid square %d", square)
+		}
+		squares = append(squares, square)
+	}
+	return squares, nil
+}
+
+// ToXY converts the square number 1-32 into board coordinates, the first
+// row has the dark squares on odd columns
+func ToXY(square int) (x, y int) {
+	y = (square - 1) / 4
+	x = (square-1)%4*2 + 1
+	if y%2 == 1 {
+		x--
+	}
+	return
+}
+
+// ToSquare converts the board coordinates into square number, returns 0 for
+// the light or out of board squares
+func ToSquare(x, y int) int {
+	if x < 0 || x >= Size || y < 0 || y >= Size || (x+y)%2 == 0 {
+		return 0
+	}
+	return y*4 + x/2 + 1
+}
+
+type Board struct {
+	// Squares are indexed from 0, square number 1 is at index 0
+	Field [Squares]Piece
+}
+
+// At returns the piece on square
+func (b *Board) At(square int) Piece {
+	return b.Field[square-1]
+}
+
+type Checkers struct {
+	Board
+	Turn Player
+	// QuietMoves counts the moves without captures or man moves
+	QuietMoves int
+	State
+}
+
+// NewCheckers sets up the pieces on the first and last three rows
+func NewCheckers() Checkers {
+	game := Checkers{Turn: FirstPlayer, State: StartState}
+
+	for i := 0; i < 12; i++ {
+		game.Field[i] = FirstMan
+		game.Field[Squares-1-i] = SecondMan
+	}
+	return game
+}
+
+func (c *Checkers) IsOver() bool {
+	return c.State == GameOverState || c.State == WinState || c.State == DrawState
+}
+
+// directions returns the row directions where the piece could move
+func directions(piece Piece) []int {
+	if piece.IsKing() {
+		return []int{-1, 1}
+	}
+	if piece.Owner() == FirstPlayer {
+		return []int{1}
+	}
+	return []int{-1}
+}
+
+// isKingRow checks if the man reaches the crowning row
+func isKingRow(piece Piece, square int) bool {
+	_, y := ToXY(square)
+	return (piece == FirstMan && y == Size-1) || (piece == SecondMan && y == 0)
+}
+
+// jumps finds all the jump sequences from square, the piece continues
+// jumping as long as possible and stops when crowned
+func (c *Checkers) jumps(board Board, square int, piece Piece, move Move) []Move {
+	var moves []Move
+	x, y := ToXY(square)
+
+	for _, dy := range directions(piece) {
+		for _, dx := range []int{-1, 1} {
+			over := ToSquare(x+dx, y+dy)
+			to := ToSquare(x+2*dx, y+2*dy)
+
+			if over == 0 || to == 0 || board.At(to) != Empty ||
+				board.At(over).Owner() != piece.Owner().Opponent() {
+				continue
+			}
+
+			next := Move{
+				Path:     append(append([]int{}, move.Path...), to),
+				Captures: append(append([]int{}, move.Captures...), over),
+			}
+
+			nextBoard := board
+			nextBoard.Field[square-1] = Empty
+			nextBoard.Field[over-1] = Empty
+			nextBoard.Field[to-1] = piece
+
+			if isKingRow(piece, to) {
+				moves = append(moves, next)
+				continue
+			}
+
+			if further := c.jumps(nextBoard, to, piece, next); len(further) > 0 {
+				moves = append(moves, further...)
+			} else {
+				moves = append(moves, next)
+			}
+		}
+	}
+	return moves
+}
+
+// GetLegalMoves returns the moves of player, jumps are forced
+func (c *Checkers) GetLegalMoves(player Player) []Move {
+	var steps, jumps []Move
+
+	for square := 1; square <= Squares; square++ {
+		piece := c.At(square)
+		if piece.Owner() != player {
+			continue
+		}
+
+		jumps = append(jumps, c.jumps(c.Board, square, piece, Move{Path: []int{square}})...)
+
+		x, y := ToXY(square)
+		for _, dy := range directions(piece) {
+			for _, dx := range []int{-1, 1} {
+				to := ToSquare(x+dx, y+dy)
+				if to != 0 && c.At(to) == Empty {
+					steps = append(steps, Move{Path: []int{square, to}})
+				}
+			}
+		}
+	}
+
+	if len(jumps) > 0 {
+		return jumps
+	}
+	return steps
+}
+
+// FindMove matches the squares against the legal moves, for jumps only the
+// start and end squares are needed when there's only one path between them
+func (c *Checkers) FindMove(squares []int) (Move, error) {
+	var found []Move
+
+	for _, move := range c.GetLegalMoves(c.Turn) {
+		if equalPath(move.Path, squares) {
+			return move, nil
+		}
+
+		if len(squares) == 2 && move.Path[0] == squares[0] && move.Path[len(move.Path)-1] == squares[1] {
+			found = append(found, move)
+		}
+	}
+
+	if len(found) == 1 {
+		return found[0], nil
+	}
+
+	if len(found) > 1 {
+		return Move{}, errors.New("Ambiguous move, give the full jump path")
+	}
+	return Move{}, errors.New("Illegal move")
+}
+
+func equalPath(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// MakeMove applies the legal move for the current player, the player
+// without any legal moves on turn loses the game
+func (c *Checkers) MakeMove(move Move) error {
+	if c.IsOver() {
+		return errors.New("Game over could not make move")
+	}
+
+	if len(move.Path) < 2 {
+		return errors.New("Move should have at least two squares")
+	}
+
+	from := move.Path[0]
+	to := move.Path[len(move.Path)-1]
+	piece := c.At(from)
+
+	if piece.Owner() != c.Turn {
+		return fmt.Errorf("No piece of player on square %d", from)
+	}
+
+	c.Field[from-1] = Empty
+	for _, square := range move.Captures {
+		c.Field[square-1] = Empty
+	}
+
+	if move.IsJump() || !piece.IsKing() {
+		c.QuietMoves = 0
+	} else {
+		c.QuietMoves++
+	}
+
+	if isKingRow(piece, to) {
+		piece = piece.Crown()
+	}
+	c.Field[to-1] = piece
+
+	c.State = TurnState
+	c.Turn = c.Turn.Opponent()
+
+	if len(c.GetLegalMoves(c.Turn)) == 0 {
+		// Winner is kept in turn
+		c.State = WinState
+		c.Turn = c.Turn.Opponent()
+	} else if c.QuietMoves >= MaxQuietMoves {
+		c.State = DrawState
+	}
+	return nil
+}
+
+// Winner returns the winner player when the game is won
+func (c *Checkers) Winner() Player {
+	if c.State != WinState {
+		return UnkownPlayer
+	}
+	return c.Turn
+}
+
+// Count returns the number of men and kings of the player
+func (c *Checkers) Count(player Player) (men, kings int) {
+	for _, piece := range c.Field {
+		if piece.Owner() != player {
+			continue
+		}
+		if piece.IsKing() {
+			kings++
+		} else {
+			men++
+		}
+	}
+	return
+}
+
+func (c *Checkers) GetBoardAsString() string {
+	cells := make([]byte, Squares)
+	for i, piece := range c.Field {
+		cells[i] = byte('0' + piece)
+	}
+	return string(cells)
+}
+
+// CreateFromString restores the board from the board string
+func CreateFromString(value string, turn Player, state State) (Checkers, error) {
+	game := Checkers{Turn: turn, State: state}
+
+	if len(value) != Squares {
+		return game, fmt.Errorf("Invalid board length %d", len(value))
+	}
+
+	for i := 0; i < Squares; i++ {
+		piece := Piece(value[i] - '0')
+		if value[i] < '0' || piece > SecondKing {
+			return game, fmt.Errorf("Invalid board square '%c'", value[i])
+		}
+		game.Field[i] = piece
+	}
+	return game, nil
+}
@@ -0,0 +1,70 @@
+package checkers
+
+import "testing"
+
+func TestOpeningMoves(t *testing.T) {
+	game := NewCheckers()
+	moves := game.GetLegalMoves(FirstPlayer)
+
+	if len(moves) != 7 {
+		t.Fatal("Black should have 7 opening moves", moves)
+	}
+
+	move, err := game.FindMove([]int{11, 15})
+	if err != nil {
+		t.Fatal("Move 11-15 should be legal", err)
+	}
+
+	if err = game.MakeMove(move); err != nil || game.Turn != SecondPlayer {
+		t.Error("Turn should switch to white", err)
+	}
+}
+
+func TestForcedMultiJump(t *testing.T) {
+	board := []byte("00000000000000000000000000000000")
+	board[10] = '1' // 11 black man
+	board[14] = '2' // 15 white man
+	board[22] = '2' // 23 white man
+	board[29] = '2' // 30 white man, keeps white in game
+
+	game, err := CreateFromString(string(board), FirstPlayer, TurnState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moves := game.GetLegalMoves(FirstPlayer)
+	if len(moves) != 1 || moves[0].String() != "11x18x27" {
+		t.Fatal("Only the double jump should be legal", moves)
+	}
+
+	if _, err = game.FindMove([]int{11, 16}); err == nil {
+		t.Error("Simple move should not be allowed when jump is available")
+	}
+
+	move, err := game.FindMove([]int{11, 27})
+	if err != nil {
+		t.Fatal("Short jump notation should be found", err)
+	}
+	game.MakeMove(move)
+
+	if game.At(27) != FirstMan || game.At(15) != Empty || game.At(23) != Empty {
+		t.Error("Jumped pieces should be captured", game.GetBoardAsString())
+	}
+}
+
+func TestCrowning(t *testing.T) {
+	board := []byte("00000000000000000000000000000000")
+	board[25] = '1' // 26 black man
+	board[0] = '2'  // 1 white man
+
+	game, _ := CreateFromString(string(board), FirstPlayer, TurnState)
+	move, err := game.FindMove([]int{26, 30})
+	if err != nil {
+		t.Fatal("Move 26-30 should be legal", err)
+	}
+	game.MakeMove(move)
+
+	if game.At(30) != FirstKing {
+		t.Error("Man should be crowned on the last row")
+	}
+}
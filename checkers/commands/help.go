@@ -0,0 +1,48 @@
+package commands
+
+import "github.com/slack-games/slack-client"
+
+const helpText = `
+To start a new game with the bot type _/checkers start_ or challenge a teammate _/checkers start @user_.
+Black moves first from squares 1-12 and white from squares 21-32, the squares are numbered on the board.
+Make move by typing _/checkers move 11-15_, jumps are written as _/checkers move 11x18x25_.
+Captures are forced and the piece reaching the last row is crowned as king.
+
+Good luck!
+`
+
+// HelpCommand show the possible info about available commands for user
+func HelpCommand() slack.ResponseMessage {
+
+	attachments := []slack.Attachment{
+		slack.Attachment{
+			Title: "/checkers start - starts a new game with the bot",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/checkers start @user - challenges teammate to a new game",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/checkers current - show the state of current game",
+			Color: "#FF4F20",
+		},
+		slack.Attachment{
+			Title: "/checkers move 11-15 or 11x18x25 - make move on the current board",
+			Color: "#004FDD",
+		},
+		slack.Attachment{
+			Title: "/checkers boards [text|image] - show the boards as text or images",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/checkers help - Shows help message",
+			Color: "#76A0A0",
+		},
+	}
+
+	return slack.ResponseMessage{
+		Text:        helpText,
+		Attachments: attachments,
+	}
+}
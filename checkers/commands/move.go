@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/checkers"
+	chkdatastore "github.com/slack-games/slack-server/checkers/datastore"
+	"github.com/slack-games/slack-server/datastore"
+)
+
+// MoveCommand makes the user move, in the bot games the bot replies
+// right away
+func MoveCommand(db *sqlx.DB, teamID, userID string, squares []int) slack.ResponseMessage {
+	state, err := chkdatastore.GetUserLastState(db, teamID, userID)
+
+	if err != nil {
+		// No state found
+		if err == sql.ErrNoRows {
+			return slack.TextOnly("You can not make any moves before the game has started `/checkers start`")
+		}
+		log.Println("Error could not get the user state", err)
+		return slack.TextOnly("Could not get the last game state")
+	}
+
+	// Check the game states
+	if isGameOver(state) {
+		log.Println("Game is already over")
+		return slack.TextOnly("Current game is over, but you can always start a new game `/checkers start`")
+	}
+
+	if state.TurnID != userID {
+		return slack.TextOnly(fmt.Sprintf("It's not your turn, waiting for <@%s>", state.TurnID))
+	}
+
+	game, err := chkdatastore.CreateCheckersBoard(state)
+	if err != nil {
+		log.Println("Could not create the board from state", err)
+		return slack.TextOnly("Could not read the game state")
+	}
+
+	move, err := game.FindMove(squares)
+	if err != nil {
+		return slack.TextOnly(fmt.Sprintf("%s, legal moves are %s :scream_cat:",
+			err, formatMoves(game.GetLegalMoves(game.Turn))))
+	}
+
+	game.MakeMove(move)
+	lastMove := move.String()
+	message := fmt.Sprintf(":space_invader: You made move *[%s]*", lastMove)
+
+	if state.IsBotGame() && !game.IsOver() {
+		reply := checkers.BestMove(*game)
+		if err = game.MakeMove(reply); err != nil {
+			log.Println("Bot should be able to make move", reply, err)
+		}
+
+		lastMove = reply.String()
+		message += fmt.Sprintf(", bot replied *[%s]*", lastMove)
+	} else if !game.IsOver() {
+		message += fmt.Sprintf(", now it's <@%s> turn", state.GetOpponentID(userID))
+	}
+
+	newState := chkdatastore.CreateStateFromBoard(game, state, lastMove)
+	stateID, err := chkdatastore.NewState(db, *newState)
+	if err == datastore.ErrConflict {
+		return slack.TextOnly("The game changed meanwhile, check it with `/checkers current` and try again")
+	}
+	if err != nil {
+		log.Println("Could not save the new state", err)
+		return slack.TextOnly("Could not save the move")
+	}
+
+	if game.IsOver() {
+		message += ". " + resultMessage(game, *newState, userID)
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, "The current game state", stateID)},
+	}
+}
+
+func resultMessage(game *checkers.Checkers, state chkdatastore.State, userID string) string {
+	switch game.Winner() {
+	case state.GetPlayer(userID):
+		return ":tada: You won the game :tada:"
+	case checkers.UnkownPlayer:
+		return "It's a draw, no captures or man moves for too long"
+	}
+	return fmt.Sprintf("Game won by <@%s>", state.GetUserID(game.Winner()))
+}
+
+func formatMoves(moves []checkers.Move) string {
+	notations := make([]string, len(moves))
+	for i, move := range moves {
+		notations[i] = move.String()
+	}
+	return strings.Join(notations, ", ")
+}
@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/checkers"
+	chkdatastore "github.com/slack-games/slack-server/checkers/datastore"
+	"github.com/slack-games/slack-server/datastore"
+)
+
+// StartCommand starts a new game against the opponent, when the opponent is
+// the bot the colors are picked randomly, otherwise challenger plays black
+func StartCommand(db *sqlx.DB, teamID, userID, opponentID string) slack.ResponseMessage {
+	if userID == opponentID {
+		return slack.TextOnly("You can not challenge yourself, pick a teammate or play with the bot")
+	}
+
+	for _, id := range []string{userID, opponentID} {
+		// Bot could play many games at once
+		if id == chkdatastore.BotUserID {
+			continue
+		}
+
+		state, err := chkdatastore.GetUserLastState(db, teamID, id)
+		if err != nil && err != sql.ErrNoRows {
+			log.Println("Error could not get the user state", err)
+			return slack.TextOnly("Could not get the last game state")
+		}
+
+		if err == nil && !isGameOver(state) {
+			if id == userID {
+				return slack.ResponseMessage{
+					Text:        "There's already existing a game, you have to finish it before starting a new",
+					Attachments: []slack.Attachment{imageAttachment(db, "Last game state", state.StateID)},
+				}
+			}
+			return slack.TextOnly("Your opponent is already playing, try again later")
+		}
+	}
+
+	stateID, state := createNewState(db, teamID, userID, opponentID)
+
+	message := fmt.Sprintf("Created a new game with <@%s>, you play %s. To make move `/checkers move 11-15`.",
+		opponentID, getColor(state, userID))
+	if opponentID == chkdatastore.BotUserID {
+		message = fmt.Sprintf("Created a new game with the bot :robot_face:, you play %s. To make move `/checkers move 11-15`.",
+			getColor(state, userID))
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, "New game state", stateID)},
+	}
+}
+
+func createNewState(db *sqlx.DB, teamID, userID, opponentID string) (ID string, state chkdatastore.State) {
+	seed := datastore.NewSeed()
+	game := checkers.NewCheckers()
+	lastMove := ""
+
+	state = chkdatastore.State{
+		TeamID:       teamID,
+		FirstUserID:  userID,
+		SecondUserID: opponentID,
+		Seed:         seed,
+		ParentID:     "00000000-0000-0000-0000-000000000000",
+	}
+
+	// Bot plays with black and makes the first move
+	if opponentID == chkdatastore.BotUserID && datastore.Rand(seed, 0).Intn(2) == 0 {
+		state.FirstUserID = opponentID
+		state.SecondUserID = userID
+
+		move := checkers.BestMove(game)
+		game.MakeMove(move)
+		lastMove = move.String()
+	}
+
+	state = *chkdatastore.CreateStateFromBoard(&game, state, lastMove)
+
+	log.Println("Create a new state")
+	ID, err := chkdatastore.NewState(db, state)
+	if err != nil {
+		log.Fatalln("Could not create a new state", err)
+	}
+	return
+}
+
+func getColor(state chkdatastore.State, userID string) string {
+	if state.FirstUserID == userID {
+		return ":black_circle: black (squares 1-12)"
+	}
+	return ":white_circle: white (squares 21-32)"
+}
+
+func isGameOver(state chkdatastore.State) bool {
+	return state.Mode == fmt.Sprintf("%s", checkers.GameOverState) ||
+		state.Mode == fmt.Sprintf("%s", checkers.WinState) ||
+		state.Mode == fmt.Sprintf("%s", checkers.DrawState) ||
+		state.Mode == datastore.ForfeitMode
+}
+
+func imageAttachment(db *sqlx.DB, title, stateID string) slack.Attachment {
+	return slack.Attachment{
+		Title:    title,
+		Fallback: boardText(db, stateID),
+		ImageURL: fmt.Sprintf("%s/game/checkers/image/%s", os.Getenv("BASE_PATH"), stateID),
+		Color:    "#764FA5",
+	}
+}
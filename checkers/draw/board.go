@@ -0,0 +1,130 @@
+package draw
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"os"
+
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dimg"
+	kit "github.com/llgcode/draw2d/draw2dkit"
+	"github.com/slack-games/slack-server/checkers"
+)
+
+const (
+	Width    = 350
+	Height   = 350
+	Offset   = 15.0
+	CellSize = 40.0
+)
+
+var DefaultColor, FirstColor, SecondColor color.RGBA
+var LightColor, DarkColor, HighlightColor color.RGBA
+
+func init() {
+	// #444444
+	DefaultColor = color.RGBA{0x44, 0x44, 0x44, 0xff}
+	// #111111
+	FirstColor = color.RGBA{0x11, 0x11, 0x11, 0xff}
+	// #F5F5F5
+	SecondColor = color.RGBA{0xf5, 0xf5, 0xf5, 0xff}
+	// #F0D9B5
+	LightColor = color.RGBA{0xf0, 0xd9, 0xb5, 0xff}
+	// #B58863
+	DarkColor = color.RGBA{0xb5, 0x88, 0x63, 0xff}
+	// #E5C100
+	HighlightColor = color.RGBA{0xe5, 0xc1, 0x00, 0xff}
+}
+
+func squareCorner(square int) (float64, float64) {
+	x, y := checkers.ToXY(square)
+	return Offset + float64(x)*CellSize, Offset + float64(y)*CellSize
+}
+
+// DrawSquares draws the board with the dark square numbers
+func DrawSquares(gc *draw2dimg.GraphicContext, highlight []int) {
+	gc.Save()
+	gc.SetFillColor(LightColor)
+	kit.Rectangle(gc, Offset, Offset, Offset+CellSize*checkers.Size, Offset+CellSize*checkers.Size)
+	gc.Fill()
+
+	for square := 1; square <= checkers.Squares; square++ {
+		xPos, yPos := squareCorner(square)
+
+		gc.SetFillColor(DarkColor)
+		for _, marked := range highlight {
+			if marked == square {
+				gc.SetFillColor(HighlightColor)
+			}
+		}
+		kit.Rectangle(gc, xPos, yPos, xPos+CellSize, yPos+CellSize)
+		gc.Fill()
+
+		gc.SetFillColor(color.White)
+		gc.SetFontSize(8)
+		gc.FillStringAt(fmt.Sprintf("%d", square), xPos+2, yPos+10)
+	}
+	gc.Restore()
+}
+
+// DrawPieces draws men as discs and kings with additional ring
+func DrawPieces(gc *draw2dimg.GraphicContext, board checkers.Board) {
+	for square := 1; square <= checkers.Squares; square++ {
+		piece := board.At(square)
+		if piece == checkers.Empty {
+			continue
+		}
+
+		xPos, yPos := squareCorner(square)
+		xPos, yPos = xPos+CellSize/2, yPos+CellSize/2
+
+		fill, stroke := FirstColor, SecondColor
+		if piece.Owner() == checkers.SecondPlayer {
+			fill, stroke = SecondColor, FirstColor
+		}
+
+		gc.Save()
+		gc.SetFillColor(fill)
+		gc.SetStrokeColor(DefaultColor)
+		gc.SetLineWidth(1)
+		kit.Circle(gc, xPos, yPos, CellSize/2-5)
+		gc.FillStroke()
+
+		if piece.IsKing() {
+			gc.SetStrokeColor(stroke)
+			gc.SetLineWidth(2)
+			kit.Circle(gc, xPos, yPos, CellSize/4)
+			gc.Stroke()
+		}
+		gc.Restore()
+	}
+}
+
+func Draw(game *checkers.Checkers, lastMove string) image.Image {
+
+	// Initialize the graphic context on an RGBA image
+	dest := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	gc := draw2dimg.NewGraphicContext(dest)
+
+	fontPath := os.Getenv("FONT_PATH")
+	if fontPath == "" {
+		log.Fatalln("No FONT_PATH has been set")
+	}
+
+	draw2d.SetFontFolder(fontPath)
+
+	gc.SetFontData(draw2d.FontData{
+		Name:   "Surface",
+		Family: draw2d.FontFamilySans,
+		Style:  draw2d.FontStyleBold,
+	})
+
+	highlight, _ := checkers.ParseMove(lastMove)
+
+	DrawSquares(gc, highlight)
+	DrawPieces(gc, game.Board)
+
+	return dest
+}
@@ -0,0 +1,42 @@
+package draw
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/slack-games/slack-server/checkers"
+)
+
+// pieceSymbols are the emojis of the pieces
+var pieceSymbols = map[checkers.Piece]string{
+	checkers.FirstMan:   ":black_circle:",
+	checkers.SecondMan:  ":white_circle:",
+	checkers.FirstKing:  ":black_medium_square:",
+	checkers.SecondKing: ":white_medium_square:",
+}
+
+// Text returns the board as the emoji grid, for the clients without images.
+// The dark squares are numbered on the right side of each row
+func Text(game *checkers.Checkers) string {
+	var buffer bytes.Buffer
+
+	for y := 0; y < checkers.Size; y++ {
+		for x := 0; x < checkers.Size; x++ {
+			square := checkers.ToSquare(x, y)
+
+			switch {
+			case square == 0:
+				buffer.WriteString(":white_large_square:")
+			case game.At(square) == checkers.Empty:
+				buffer.WriteString(":black_large_square:")
+			default:
+				buffer.WriteString(pieceSymbols[game.At(square)])
+			}
+		}
+		first := checkers.ToSquare(1-y%2, y)
+		buffer.WriteString(fmt.Sprintf(" %d-%d\n", first, first+3))
+	}
+
+	buffer.WriteString("Black :black_circle: white :white_circle:, kings :black_medium_square: :white_medium_square:")
+	return buffer.String()
+}
@@ -0,0 +1,92 @@
+package checkers
+
+import "github.com/slack-games/slack-server/minmax"
+
+const (
+	// MaxInt maximum board value
+	MaxInt = minmax.MaxInt
+	// MinInt minimum board value
+	MinInt = minmax.MinInt
+	// Depth default search depth for the bot
+	Depth = 6
+)
+
+func evaluateBoard(game Checkers, maximizer Player) int {
+	if game.IsOver() {
+		switch game.Winner() {
+		case maximizer:
+			return MaxInt / 2
+		case maximizer.Opponent():
+			return MinInt / 2
+		}
+		return 0
+	}
+
+	score := 0
+	for i, piece := range game.Field {
+		value := 0
+		_, y := ToXY(i + 1)
+
+		switch piece {
+		case FirstMan:
+			// Men closer to the crowning row are worth more
+			value = 100 + y*2
+		case SecondMan:
+			value = 100 + (Size-1-y)*2
+		case FirstKing, SecondKing:
+			value = 160
+		}
+
+		if piece.Owner() == maximizer {
+			score += value
+		} else {
+			score -= value
+		}
+	}
+	return score
+}
+
+// position is the game searched by the bot, the legal moves are kept for
+// the move indexes
+type position struct {
+	game      Checkers
+	maximizer Player
+	moves     []Move
+}
+
+func newPosition(game Checkers, maximizer Player) *position {
+	return &position{game: game, maximizer: maximizer, moves: game.GetLegalMoves(game.Turn)}
+}
+
+func (p *position) Moves() int {
+	return len(p.moves)
+}
+
+func (p *position) Play(move int) minmax.Position {
+	newGame := p.game
+	newGame.MakeMove(p.moves[move])
+	return newPosition(newGame, p.maximizer)
+}
+
+func (p *position) Maximizing() bool {
+	return p.game.Turn == p.maximizer
+}
+
+func (p *position) IsOver() bool {
+	return p.game.IsOver()
+}
+
+func (p *position) Evaluate() int {
+	return evaluateBoard(p.game, p.maximizer)
+}
+
+// BestMove returns the bot move for the current turn player
+func BestMove(game Checkers) Move {
+	position := newPosition(game, game.Turn)
+
+	move := minmax.Best(position, Depth)
+	if move == minmax.NoMove {
+		return Move{}
+	}
+	return position.moves[move]
+}
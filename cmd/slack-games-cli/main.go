@@ -0,0 +1,251 @@
+// Command slack-games-cli plays the games in the terminal without Slack. The
+// slash commands are handled by the same controllers and game commands as
+// the server, so the games need the Postgres database like the server.
+// There's no in-memory store, the game commands query Postgres directly so
+// a scratch database is used for the local play.
+//
+// Usage:
+//
+//	slack-games-cli -db postgres://localhost/games -schema data/deploy.sql
+//	slack-games-cli -db postgres://localhost/games -script session.txt
+//
+// Each line is a slash command like "/ttt start" or "/hng guess e". The line
+// "user U000000002 Bob" switches the playing user so the games against other
+// players could be played too, "channel C0000001" switches the channel. Lines
+// starting with # are comments. With the -seed flag the same script plays
+// always the same games.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/bluesuncorp/validator.v8"
+
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/joho/godotenv/autoload"
+	_ "github.com/lib/pq"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/controller"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/secret"
+	"github.com/slack-games/slack-server/server"
+)
+
+// appToken is the app token of the local requests
+const appToken = "cli"
+
+// paths are the routes of the slash commands
+var paths = map[string]string{
+	"/ttt":        "/game/tictactoe",
+	"/hng":        "/game/hangman",
+	"/battleship": "/game/battleship",
+	"/reversi":    "/game/reversi",
+	"/checkers":   "/game/checkers",
+	"/mastermind": "/game/mastermind",
+	"/dots":       "/game/dots",
+}
+
+// Session is the local Slack user playing the games
+type Session struct {
+	Router    *mux.Router
+	TeamID    string
+	UserID    string
+	Name      string
+	ChannelID string
+}
+
+// newRouter registers the game controllers like the server does
+func newRouter(context server.Context) *mux.Router {
+	router := mux.NewRouter()
+	gameRouter := router.PathPrefix("/game").Subrouter()
+
+	hangmanController := controller.HangmanController{Context: context}
+	hangmanController.Register(gameRouter)
+
+	tictactoeController := controller.TictactoeController{Context: context}
+	tictactoeController.Register(gameRouter)
+
+	battleshipController := controller.BattleshipController{Context: context}
+	battleshipController.Register(gameRouter)
+
+	reversiController := controller.ReversiController{Context: context}
+	reversiController.Register(gameRouter)
+
+	checkersController := controller.CheckersController{Context: context}
+	checkersController.Register(gameRouter)
+
+	mastermindController := controller.MastermindController{Context: context}
+	mastermindController.Register(gameRouter)
+
+	dotsController := controller.DotsController{Context: context}
+	dotsController.Register(gameRouter)
+
+	return router
+}
+
+// Command sends the slash command as the session user and returns the
+// response
+func (s *Session) Command(command, text string) (slack.ResponseMessage, error) {
+	var message slack.ResponseMessage
+
+	path, found := paths[command]
+	if !found {
+		return message, fmt.Errorf("Unknown command %s", command)
+	}
+
+	data := url.Values{}
+	data.Set("token", appToken)
+	data.Set("command", command)
+	data.Set("text", text)
+	data.Set("team_id", s.TeamID)
+	data.Set("team_domain", "local")
+	data.Set("user_id", s.UserID)
+	data.Set("user_name", s.Name)
+	data.Set("channel_id", s.ChannelID)
+	data.Set("channel_name", "local")
+
+	r, err := http.NewRequest("POST", path, bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return message, err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	s.Router.ServeHTTP(w, r)
+
+	err = json.Unmarshal(w.Body.Bytes(), &message)
+	return message, err
+}
+
+// Run reads the commands line by line and writes the responses
+func (s *Session) Run(in io.Reader, out io.Writer, echo bool) {
+	scanner := bufio.NewScanner(in)
+
+	for {
+		if !echo {
+			fmt.Fprint(out, "> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if echo {
+			fmt.Fprintf(out, "> %s\n", line)
+		}
+
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "quit", "exit":
+			return
+
+		case "user":
+			if len(fields) < 2 {
+				fmt.Fprintln(out, "Usage: user [user ID] [name]")
+				continue
+			}
+			s.UserID, s.Name = fields[1], fields[1]
+			if len(fields) > 2 {
+				s.Name = fields[2]
+			}
+			fmt.Fprintf(out, "Playing as %s (%s)\n", s.Name, s.UserID)
+
+		case "channel":
+			if len(fields) != 2 {
+				fmt.Fprintln(out, "Usage: channel [channel ID]")
+				continue
+			}
+			s.ChannelID = fields[1]
+			fmt.Fprintf(out, "Playing in %s\n", s.ChannelID)
+
+		default:
+			message, err := s.Command(fields[0], strings.Join(fields[1:], " "))
+			if err != nil {
+				fmt.Fprintln(out, "Error:", err)
+				continue
+			}
+			fmt.Fprint(out, Render(message))
+		}
+	}
+}
+
+func main() {
+	dbURL := flag.String("db", os.Getenv("DB_URL"), "Postgres database URL, defaults to DB_URL. There's no in-memory store")
+	schema := flag.String("schema", "", "schema file loaded before the session, like data/deploy.sql")
+	script := flag.String("script", "", "file of commands to run instead of the prompt")
+	teamID := flag.String("team", "T00000001", "team ID of the player")
+	userID := flag.String("user", "U000000001", "user ID of the player")
+	name := flag.String("name", "player", "user name of the player")
+	seed := flag.Int64("seed", 0, "seed of the first game, the next games get the following seeds")
+	verbose := flag.Bool("v", false, "show the server logs")
+	flag.Parse()
+
+	if *dbURL == "" {
+		log.Fatalln("No database URL provided, use -db or DB_URL")
+	}
+
+	db := sqlx.MustConnect("postgres", *dbURL)
+
+	if *schema != "" {
+		if _, err := sqlx.LoadFile(db, *schema); err != nil {
+			log.Fatalln("Could not load the schema", err)
+		}
+	}
+
+	// The same seed and commands play always the same games
+	if *seed != 0 {
+		next := *seed
+		datastore.NewSeed = func() int64 {
+			next++
+			return next - 1
+		}
+	}
+
+	context := server.Context{
+		Db:       db,
+		Validate: validator.New(&validator.Config{TagName: "validate"}),
+		Config:   server.Config{SlackToken: appToken},
+		// The images are not shown, the key only has to sign the URLs
+		ImageSigner: secret.NewSigner([]byte("slack-games-cli")),
+	}
+
+	session := &Session{
+		Router:    newRouter(context),
+		TeamID:    *teamID,
+		UserID:    *userID,
+		Name:      *name,
+		ChannelID: "C00000001",
+	}
+
+	in, echo := io.Reader(os.Stdin), false
+	if *script != "" {
+		file, err := os.Open(*script)
+		if err != nil {
+			log.Fatalln("Could not open the script", err)
+		}
+		defer file.Close()
+
+		in, echo = file, true
+	}
+
+	if !*verbose {
+		log.SetOutput(ioutil.Discard)
+	}
+	session.Run(in, os.Stdout, echo)
+}
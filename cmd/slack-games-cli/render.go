@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/slack-games/slack-client"
+)
+
+// Matches the Slack user mentions <@U123> and <@U123|name>
+var mentionRegexp = regexp.MustCompile("<@(\\w+)(?:\\|[^>]*)?>")
+
+// emojis are the board emojis drawn with plain characters in the terminal
+var emojis = strings.NewReplacer(
+	":o:", " O ", ":x:", " X ",
+	":one:", " 1 ", ":two:", " 2 ", ":three:", " 3 ", ":four:", " 4 ", ":five:", " 5 ",
+	":six:", " 6 ", ":seven:", " 7 ", ":eight:", " 8 ", ":nine:", " 9 ",
+	":black_circle:", " B ", ":white_circle:", " W ",
+	":black_medium_square:", "[B]", ":white_medium_square:", "[W]",
+	":black_large_square:", " . ", ":white_large_square:", "   ",
+	":green_square:", " . ", ":small_orange_diamond:", " * ",
+	":heart:", "<3", ":skull:", "x_x",
+	"```\n", "", "\n```", "", "```", "",
+)
+
+// Render converts the command response into terminal text, the images are
+// replaced with the text boards of the attachments
+func Render(message slack.ResponseMessage) string {
+	var buffer bytes.Buffer
+
+	if message.Text != "" {
+		buffer.WriteString(strings.TrimSpace(message.Text) + "\n")
+	}
+
+	for _, attachment := range message.Attachments {
+		if attachment.Title != "" {
+			buffer.WriteString(fmt.Sprintf("== %s\n", attachment.Title))
+		}
+		if attachment.Text != "" {
+			buffer.WriteString(attachment.Text + "\n")
+		}
+		if attachment.ImageURL != "" && attachment.Fallback != "" {
+			buffer.WriteString(attachment.Fallback + "\n")
+		}
+	}
+
+	return mentionRegexp.ReplaceAllString(emojis.Replace(buffer.String()), "@$1")
+}
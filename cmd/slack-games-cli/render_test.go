@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/slack-games/slack-client"
+)
+
+func TestRender(t *testing.T) {
+	message := slack.ResponseMessage{
+		Text: "Your turn <@U000000001|jim>",
+		Attachments: []slack.Attachment{
+			slack.Attachment{
+				Title:    "Board",
+				Fallback: ":o::x::three:",
+				ImageURL: "http://localhost/image",
+			},
+		},
+	}
+
+	expected := "Your turn @U000000001\n== Board\n O  X  3 \n"
+	if text := Render(message); text != expected {
+		t.Errorf("Expected %q, got %q", expected, text)
+	}
+}
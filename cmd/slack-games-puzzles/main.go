@@ -0,0 +1,90 @@
+// Command slack-games-puzzles generates the daily puzzles into the database
+// ahead of time, the days which already have the puzzle are skipped. The
+// puzzles of the day are always the same for the same day, the tic-tac-toe
+// positions are found by searching through every position of the game.
+//
+// Usage:
+//
+//	slack-games-puzzles -db postgres://localhost/games -days 30
+//	slack-games-puzzles -db postgres://localhost/games -from 2017-01-01 -days 365
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/joho/godotenv/autoload"
+	_ "github.com/lib/pq"
+	"github.com/slack-games/slack-hangman"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-tictactoe"
+)
+
+// tictactoePuzzle picks the win in two position of the day
+func tictactoePuzzle(positions []tictactoe.TicTacToe, day time.Time) datastore.Puzzle {
+	game := positions[datastore.Rand(day.Unix(), 0).Intn(len(positions))]
+
+	var solution []string
+	for _, spot := range tictactoe.WinningMoves(game) {
+		solution = append(solution, fmt.Sprintf("%d", spot.ToMove()+1))
+	}
+
+	return datastore.Puzzle{
+		Game:     datastore.TictactoePuzzle,
+		Day:      day,
+		Position: game.GetPositionAsString(),
+		Solution: strings.Join(solution, ""),
+	}
+}
+
+// hangmanPuzzle picks the partially revealed word of the day
+func hangmanPuzzle(day time.Time) datastore.Puzzle {
+	word, current := hangman.NewPuzzle(datastore.Rand(day.Unix(), 1))
+
+	return datastore.Puzzle{
+		Game:     datastore.HangmanPuzzle,
+		Day:      day,
+		Position: current,
+		Solution: word,
+		Lives:    hangman.PuzzleLives,
+	}
+}
+
+func main() {
+	dbURL := flag.String("db", os.Getenv("DB_URL"), "database URL, defaults to DB_URL")
+	from := flag.String("from", datastore.PuzzleDay(time.Now()), "first day of the puzzles")
+	days := flag.Int("days", 30, "number of the days to generate")
+	flag.Parse()
+
+	if *dbURL == "" {
+		log.Fatalln("No database URL provided, use -db or DB_URL")
+	}
+
+	first, err := time.Parse("2006-01-02", *from)
+	if err != nil {
+		log.Fatalln("Could not read the first day", err)
+	}
+
+	db := sqlx.MustConnect("postgres", *dbURL)
+	positions := tictactoe.WinInTwo()
+	log.Println("Found", len(positions), "win in two positions")
+
+	for i := 0; i < *days; i++ {
+		day := first.AddDate(0, 0, i)
+
+		for _, puzzle := range []datastore.Puzzle{tictactoePuzzle(positions, day), hangmanPuzzle(day)} {
+			saved, err := datastore.NewPuzzle(db, puzzle)
+			if err != nil {
+				log.Fatalln("Could not save the puzzle", puzzle.Game, datastore.PuzzleDay(day), err)
+			}
+			if saved {
+				log.Println("Saved the", puzzle.Game, "puzzle of", datastore.PuzzleDay(day))
+			}
+		}
+	}
+}
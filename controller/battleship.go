@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"fmt"
+	"image/png"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/battleship"
+	btscmd "github.com/slack-games/slack-server/battleship/commands"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/server"
+)
+
+// BattleshipController battleship controller
+type BattleshipController struct {
+	Context server.Context
+}
+
+func (b *BattleshipController) isGameCommandHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		command := r.PostFormValue("command")
+
+		if command != "/battleship" {
+			sendResponse(w, slack.ResponseMessage{
+				Text:        "Make sure you have command set to /battleship",
+				Attachments: []slack.Attachment{},
+			})
+			return
+		}
+
+		log.Println("Valid battleship game command found")
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (b *BattleshipController) gameHandler(w http.ResponseWriter, r *http.Request) {
+	var message slack.ResponseMessage
+
+	input, err := decodeCommandInput(r, b.Context.Validate)
+	if err != nil {
+		log.Println("Could not parse the game input", err)
+		sendResponse(w, slack.TextOnly("Could not parse the game input"))
+		return
+	}
+
+	startRegexp, _ := regexp.Compile("^start (\\S+)$")
+	placeRegexp, _ := regexp.Compile("^place (.+)$")
+	fireRegexp, _ := regexp.Compile("^fire ([a-jA-J](10|[1-9]))$")
+
+	user, err := datastore.GetOrSaveNew(b.Context.Db, input.UserID, input.TeamID, input.EnterpriseID,
+		input.Name, input.Domain)
+	if err != nil {
+		log.Fatalln("Could not save or get the user", input.UserID, err)
+	}
+
+	switch {
+	case startRegexp.MatchString(input.Text):
+		mention := startRegexp.FindStringSubmatch(input.Text)[1]
+		message = b.startGame(input, mention)
+
+	case placeRegexp.MatchString(input.Text):
+		placement := placeRegexp.FindStringSubmatch(input.Text)[1]
+		message = btscmd.PlaceCommand(b.Context.Db, b.Context.ImageSigner, input.TeamID, input.UserID, placement)
+
+	case fireRegexp.MatchString(input.Text):
+		spot, err := battleship.ParseSpot(fireRegexp.FindStringSubmatch(input.Text)[1])
+		if err != nil {
+			message = slack.TextOnly(err.Error())
+			break
+		}
+		message = btscmd.FireCommand(b.Context.Db, b.Context.ImageSigner, input.TeamID, input.UserID, spot)
+
+	case input.Text == "current":
+		message = btscmd.CurrentCommand(b.Context.Db, b.Context.ImageSigner, input.TeamID, input.UserID)
+
+	case input.Text == "ping":
+		message = btscmd.PingCommand()
+
+	default:
+		message = btscmd.HelpCommand()
+	}
+
+	if boardsRegexp.MatchString(input.Text) {
+		message = boardsCommand(b.Context.Db, &user, boardsRegexp.FindStringSubmatch(input.Text)[1])
+	}
+
+	sendGameResponse(w, user, message)
+}
+
+func (b *BattleshipController) startGame(input *CommandInput, mention string) slack.ResponseMessage {
+	opponentID, err := getMentionedUserID(b.Context.Db, input.TeamID, mention)
+	if err == nil {
+		_, err = datastore.GetUser(b.Context.Db, input.TeamID, opponentID)
+	}
+
+	if err != nil {
+		log.Println("Could not find the opponent", mention, err)
+		return slack.TextOnly(fmt.Sprintf("Could not find the user %s, they have to play any game before", mention))
+	}
+
+	return btscmd.StartCommand(b.Context.Db, input.TeamID, input.UserID, opponentID)
+}
+
+func (b *BattleshipController) getImageHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	// The token tells whose view of the board it is, the user could not be
+	// swapped without the new signature
+	userID, err := btscmd.VerifyImageToken(b.Context.ImageSigner, vars["token"], vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid image link", 403)
+		return
+	}
+
+	image, err := btscmd.GetGameImage(b.Context.Db, vars["id"], userID)
+	if err != nil {
+		http.Error(w, "Could not get the state", 404)
+		return
+	}
+
+	err = png.Encode(w, image)
+	if err != nil {
+		http.Error(w, "Could not save the image", 500)
+		return
+	}
+}
+
+// Register creates a new subrouter for the battleship and adds the http handlers
+func (b *BattleshipController) Register(router *mux.Router) *mux.Router {
+	decoder.IgnoreUnknownKeys(true)
+	btsRouter := router.PathPrefix("/battleship").Subrouter()
+
+	btsRouter.HandleFunc("/image/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}/{token:[\\w.-]+}", b.getImageHandler).
+		Methods("GET")
+
+	gameMiddleware := alice.New(
+		slackTokenHandler(b.Context.Config.SlackToken),
+		debugFormValues,
+		b.isGameCommandHandler,
+	)
+
+	btsRouter.Methods("POST").
+		Handler(gameMiddleware.ThenFunc(b.gameHandler))
+
+	return btsRouter
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"fmt"
+	"image/png"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/checkers"
+	chkcmd "github.com/slack-games/slack-server/checkers/commands"
+	chkdatastore "github.com/slack-games/slack-server/checkers/datastore"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/server"
+)
+
+// CheckersController checkers controller
+type CheckersController struct {
+	Context server.Context
+}
+
+func (c *CheckersController) isGameCommandHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		command := r.PostFormValue("command")
+
+		if command != "/checkers" {
+			sendResponse(w, slack.TextOnly("Make sure you have command set to /checkers"))
+			return
+		}
+
+		log.Println("Valid checkers game command found")
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c *CheckersController) gameHandler(w http.ResponseWriter, r *http.Request) {
+	var message slack.ResponseMessage
+
+	input, err := decodeCommandInput(r, c.Context.Validate)
+	if err != nil {
+		log.Println("Could not parse the game input", err)
+		sendResponse(w, slack.TextOnly("Could not parse the game input"))
+		return
+	}
+
+	startRegexp, _ := regexp.Compile("^start (\\S+)$")
+	moveRegexp, _ := regexp.Compile("^move (\\S+)$")
+
+	user, err := datastore.GetOrSaveNew(c.Context.Db, input.UserID, input.TeamID, input.EnterpriseID,
+		input.Name, input.Domain)
+	if err != nil {
+		log.Fatalln("Could not save or get the user", input.UserID, err)
+	}
+
+	switch {
+	case input.Text == "start":
+		message = chkcmd.StartCommand(c.Context.Db, input.TeamID, input.UserID, chkdatastore.BotUserID)
+
+	case startRegexp.MatchString(input.Text):
+		mention := startRegexp.FindStringSubmatch(input.Text)[1]
+		message = c.startGame(input, mention)
+
+	case moveRegexp.MatchString(input.Text):
+		squares, err := checkers.ParseMove(moveRegexp.FindStringSubmatch(input.Text)[1])
+		if err != nil {
+			message = slack.TextOnly(err.Error())
+			break
+		}
+		message = chkcmd.MoveCommand(c.Context.Db, input.TeamID, input.UserID, squares)
+
+	case input.Text == "current":
+		message = chkcmd.CurrentCommand(c.Context.Db, input.TeamID, input.UserID)
+
+	case input.Text == "ping":
+		message = chkcmd.PingCommand()
+
+	default:
+		message = chkcmd.HelpCommand()
+	}
+
+	if boardsRegexp.MatchString(input.Text) {
+		message = boardsCommand(c.Context.Db, &user, boardsRegexp.FindStringSubmatch(input.Text)[1])
+	}
+
+	sendGameResponse(w, user, message)
+}
+
+func (c *CheckersController) startGame(input *CommandInput, mention string) slack.ResponseMessage {
+	opponentID, err := getMentionedUserID(c.Context.Db, input.TeamID, mention)
+	if err == nil {
+		_, err = datastore.GetUser(c.Context.Db, input.TeamID, opponentID)
+	}
+
+	if err != nil {
+		log.Println("Could not find the opponent", mention, err)
+		return slack.TextOnly(fmt.Sprintf("Could not find the user %s, they have to play any game before", mention))
+	}
+
+	return chkcmd.StartCommand(c.Context.Db, input.TeamID, input.UserID, opponentID)
+}
+
+func (c *CheckersController) getImageHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	image, err := chkcmd.GetGameImage(c.Context.Db, id)
+	if err != nil {
+		http.Error(w, "Could not get the state", 404)
+		return
+	}
+
+	err = png.Encode(w, image)
+	if err != nil {
+		http.Error(w, "Could not save the image", 500)
+		return
+	}
+}
+
+// Register creates a new subrouter for the checkers and adds the http handlers
+func (c *CheckersController) Register(router *mux.Router) *mux.Router {
+	decoder.IgnoreUnknownKeys(true)
+	chkRouter := router.PathPrefix("/checkers").Subrouter()
+
+	chkRouter.HandleFunc("/image/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}", c.getImageHandler).
+		Methods("GET")
+
+	gameMiddleware := alice.New(
+		slackTokenHandler(c.Context.Config.SlackToken),
+		debugFormValues,
+		c.isGameCommandHandler,
+	)
+
+	chkRouter.Methods("POST").
+		Handler(gameMiddleware.ThenFunc(c.gameHandler))
+
+	return chkRouter
+}
@@ -2,23 +2,65 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
-	"math/rand"
 	"net/http"
-	"time"
+	"regexp"
 
+	"gopkg.in/bluesuncorp/validator.v8"
+
+	"github.com/jmoiron/sqlx"
 	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/server"
 )
 
+// Matches the escaped Slack mention <@U123|name> or plain @name
+var mentionRegexp = regexp.MustCompile("^<@(\\w+)(?:\\|[^>]*)?>$|^@?([\\w.-]+)$")
+
+// Matches the board preference command of every game
+var boardsRegexp = regexp.MustCompile("^boards (text|image)$")
+
 // CommandInput user input for the game commands
 type CommandInput struct {
 	ChannelName string `schema:"channel_name" validate:"required"`
 	ChannelID   string `schema:"channel_id" validate:"required,alphanum"`
 	TeamID      string `schema:"team_id" validate:"required,alphanum"`
-	UserID      string `schema:"user_id" validate:"required,alphanum"`
-	Text        string `schema:"text" validate:"required"`
-	Domain      string `schema:"team_domain" validate:"required"`
-	Name        string `schema:"user_name" validate:"required"`
+	// EnterpriseID is set for the Enterprise Grid workspaces
+	EnterpriseID string `schema:"enterprise_id" validate:"omitempty,alphanum"`
+	UserID       string `schema:"user_id" validate:"required,alphanum"`
+	Text         string `schema:"text" validate:"required"`
+	Domain       string `schema:"team_domain" validate:"required"`
+	Name         string `schema:"user_name" validate:"required"`
+}
+
+// decodeCommandInput parses and validates the slash command form values
+func decodeCommandInput(r *http.Request, validate *validator.Validate) (*CommandInput, error) {
+	input := &CommandInput{}
+
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	if err := decoder.Decode(input, r.PostForm); err != nil {
+		return nil, err
+	}
+
+	if err := validate.Struct(input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// slackClient returns the Web API client of the team, nil when the team has
+// no usable bot token
+func slackClient(context server.Context, teamID string) *slack.Client {
+	client, err := context.SlackClient(teamID)
+	if err != nil {
+		log.Println("Could not get the Slack client", teamID, err)
+		return nil
+	}
+	return client
 }
 
 func sendResponse(w http.ResponseWriter, message slack.ResponseMessage) {
@@ -32,6 +74,32 @@ func sendResponse(w http.ResponseWriter, message slack.ResponseMessage) {
 	}
 }
 
+// sendGameResponse sends the game response, the images are replaced with the
+// text boards when the user prefers them
+func sendGameResponse(w http.ResponseWriter, user datastore.User, message slack.ResponseMessage) {
+	if user.TextBoards {
+		message = message.WithoutImages()
+	}
+	sendResponse(w, message)
+}
+
+// boardsCommand saves the board preference of the user, the user is updated
+// so the response already follows it
+func boardsCommand(db *sqlx.DB, user *datastore.User, style string) slack.ResponseMessage {
+	textBoards := style == "text"
+
+	if err := datastore.SetTextBoards(db, user.TeamID, user.UserID, textBoards); err != nil {
+		log.Println("Could not save the board preference", user.UserID, err)
+		return slack.TextOnly("Could not save the board preference")
+	}
+	user.TextBoards = textBoards
+
+	if textBoards {
+		return slack.TextOnly("The boards are shown as text from now on")
+	}
+	return slack.TextOnly("The boards are shown as images from now on")
+}
+
 func slackTokenHandler(token string) func(next http.Handler) http.Handler {
 	// Get function handler
 	return func(next http.Handler) http.Handler {
@@ -65,12 +133,21 @@ func debugFormValues(next http.Handler) http.Handler {
 	})
 }
 
-func randomString(strlen int) string {
-	rand.Seed(time.Now().UTC().UnixNano())
-	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
-	result := make([]byte, strlen)
-	for i := 0; i < strlen; i++ {
-		result[i] = chars[rand.Intn(len(chars))]
+// getMentionedUserID resolves the mentioned user into user ID, plain names
+// are looked up from the team users who have already played
+func getMentionedUserID(db *sqlx.DB, teamID, mention string) (string, error) {
+	matches := mentionRegexp.FindStringSubmatch(mention)
+	if matches == nil {
+		return "", fmt.Errorf("Invalid user mention '%s'", mention)
+	}
+
+	if matches[1] != "" {
+		return matches[1], nil
+	}
+
+	user, err := datastore.GetUserByName(db, teamID, matches[2])
+	if err != nil {
+		return "", err
 	}
-	return string(result)
+	return user.UserID, nil
 }
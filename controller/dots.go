@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"fmt"
+	"image/png"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/dots"
+	dabcmd "github.com/slack-games/slack-server/dots/commands"
+	"github.com/slack-games/slack-server/server"
+)
+
+// DotsController dots and boxes controller
+type DotsController struct {
+	Context server.Context
+}
+
+func (d *DotsController) isGameCommandHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		command := r.PostFormValue("command")
+
+		if command != "/dots" {
+			sendResponse(w, slack.TextOnly("Make sure you have command set to /dots"))
+			return
+		}
+
+		log.Println("Valid dots game command found")
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (d *DotsController) gameHandler(w http.ResponseWriter, r *http.Request) {
+	var message slack.ResponseMessage
+
+	input, err := decodeCommandInput(r, d.Context.Validate)
+	if err != nil {
+		log.Println("Could not parse the game input", err)
+		sendResponse(w, slack.TextOnly("Could not parse the game input"))
+		return
+	}
+
+	startRegexp, _ := regexp.Compile("^start(?: (\\d)x(\\d))?((?: \\S+)+)$")
+	lineRegexp, _ := regexp.Compile("^line (\\S+)$")
+
+	user, err := datastore.GetOrSaveNew(d.Context.Db, input.UserID, input.TeamID, input.EnterpriseID,
+		input.Name, input.Domain)
+	if err != nil {
+		log.Fatalln("Could not save or get the user", input.UserID, err)
+	}
+
+	switch {
+	case startRegexp.MatchString(input.Text):
+		matches := startRegexp.FindStringSubmatch(input.Text)
+		width, height := dots.DefaultSize, dots.DefaultSize
+		if matches[1] != "" {
+			width, _ = strconv.Atoi(matches[1])
+			height, _ = strconv.Atoi(matches[2])
+		}
+		message = d.startGame(input, strings.Fields(matches[3]), width, height)
+
+	case lineRegexp.MatchString(input.Text):
+		line, err := dots.ParseLine(lineRegexp.FindStringSubmatch(input.Text)[1])
+		if err != nil {
+			message = slack.TextOnly(err.Error())
+			break
+		}
+		message = dabcmd.LineCommand(d.Context.Db, input.TeamID, input.UserID, input.ChannelID, line)
+
+	case input.Text == "current":
+		message = dabcmd.CurrentCommand(d.Context.Db, input.TeamID, input.UserID)
+
+	case input.Text == "ping":
+		message = dabcmd.PingCommand()
+
+	default:
+		message = dabcmd.HelpCommand()
+	}
+
+	if boardsRegexp.MatchString(input.Text) {
+		message = boardsCommand(d.Context.Db, &user, boardsRegexp.FindStringSubmatch(input.Text)[1])
+	}
+
+	sendGameResponse(w, user, message)
+}
+
+// startGame resolves the mentioned users, the user starting the game makes
+// the first move
+func (d *DotsController) startGame(input *CommandInput, mentions []string, width, height int) slack.ResponseMessage {
+	players := []string{input.UserID}
+
+	for _, mention := range mentions {
+		userID, err := getMentionedUserID(d.Context.Db, input.TeamID, mention)
+		if err == nil {
+			_, err = datastore.GetUser(d.Context.Db, input.TeamID, userID)
+		}
+
+		if err != nil {
+			log.Println("Could not find the player", mention, err)
+			return slack.TextOnly(fmt.Sprintf("Could not find the user %s, they have to play any game before", mention))
+		}
+		players = append(players, userID)
+	}
+
+	return dabcmd.StartCommand(d.Context.Db, slackClient(d.Context, input.TeamID), input.TeamID, input.ChannelID,
+		players, width, height)
+}
+
+func (d *DotsController) getImageHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	image, err := dabcmd.GetGameImage(d.Context.Db, id)
+	if err != nil {
+		http.Error(w, "Could not get the state", 404)
+		return
+	}
+
+	err = png.Encode(w, image)
+	if err != nil {
+		http.Error(w, "Could not save the image", 500)
+		return
+	}
+}
+
+// Register creates a new subrouter for the dots and adds the http handlers
+func (d *DotsController) Register(router *mux.Router) *mux.Router {
+	decoder.IgnoreUnknownKeys(true)
+	dabRouter := router.PathPrefix("/dots").Subrouter()
+
+	dabRouter.HandleFunc("/image/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}", d.getImageHandler).
+		Methods("GET")
+
+	gameMiddleware := alice.New(
+		slackTokenHandler(d.Context.Config.SlackToken),
+		debugFormValues,
+		d.isGameCommandHandler,
+	)
+
+	dabRouter.Methods("POST").
+		Handler(gameMiddleware.ThenFunc(d.gameHandler))
+
+	return dabRouter
+}
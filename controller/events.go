@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/server"
+)
+
+// EventsController receives the Slack Events API events
+type EventsController struct {
+	Context server.Context
+}
+
+// slackSignatureHandler verifies the request signature with the signing
+// secret, the body is restored for the next handler
+func slackSignatureHandler(secret string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if secret == "" {
+				log.Println("Signing secret is not set, could not verify the request")
+				http.Error(w, "Invalid signature", http.StatusUnauthorized)
+				return
+			}
+
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, "Could not read the request", http.StatusBadRequest)
+				return
+			}
+
+			err = slack.VerifySignature(secret, r.Header.Get("X-Slack-Request-Timestamp"), body,
+				r.Header.Get("X-Slack-Signature"), time.Now())
+			if err != nil {
+				log.Println("Invalid Slack signature", err)
+				http.Error(w, "Invalid signature", http.StatusUnauthorized)
+				return
+			}
+
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (e *EventsController) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	envelope := slack.EventEnvelope{}
+	if err := json.NewDecoder(r.Body).Decode(&envelope); err != nil {
+		log.Println("Could not parse the event", err)
+		http.Error(w, "Could not parse the event", http.StatusBadRequest)
+		return
+	}
+
+	if envelope.Type == slack.URLVerificationType {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"challenge": envelope.Challenge})
+		return
+	}
+
+	if envelope.Type != slack.EventCallbackType {
+		log.Println("Unknown event envelope", envelope.Type)
+		return
+	}
+
+	created, err := datastore.NewEvent(e.Context.Db, envelope.EventID, envelope.TeamID)
+	if err != nil {
+		log.Println("Could not save the event", envelope.EventID, err)
+		http.Error(w, "Could not save the event", http.StatusInternalServerError)
+		return
+	}
+
+	// Retried event which has already been handled
+	if !created {
+		log.Println("Skip the duplicate event", envelope.EventID)
+		return
+	}
+
+	event, err := envelope.GetEvent()
+	if err != nil {
+		log.Println("Could not parse the inner event", err)
+		return
+	}
+
+	if err = e.handleEvent(envelope.TeamID, event); err != nil {
+		log.Println("Could not handle the event", event.Type, err)
+
+		// Let Slack retry the event
+		if err = datastore.DeleteEvent(e.Context.Db, envelope.EventID); err != nil {
+			log.Println("Could not delete the event", envelope.EventID, err)
+		}
+		http.Error(w, "Could not handle the event", http.StatusInternalServerError)
+	}
+}
+
+func (e *EventsController) handleEvent(teamID string, event *slack.Event) error {
+	var err error
+	log.Println("Received event", event.Type, teamID)
+
+	switch event.Type {
+	case slack.AppUninstalledEvent:
+		err = datastore.PurgeTeam(e.Context.Db, teamID)
+
+	case slack.TokensRevokedEvent:
+		// Only the reported kind of the tokens is revoked
+		if len(event.Tokens.Bot) > 0 {
+			err = datastore.RevokeBotToken(e.Context.Db, teamID)
+		}
+		if err == nil && len(event.Tokens.OAuth) > 0 {
+			err = datastore.RevokeUserToken(e.Context.Db, teamID)
+		}
+
+	case slack.TeamRenameEvent:
+		_, err = datastore.UpdateTeamName(e.Context.Db, teamID, event.Name)
+
+	case slack.UserChangeEvent:
+		_, err = datastore.UpdateUserName(e.Context.Db, event.User.TeamID, event.User.ID, event.User.DisplayName())
+	}
+	return err
+}
+
+// Register adds the events handler
+func (e *EventsController) Register(router *mux.Router) *mux.Router {
+	eventsMiddleware := alice.New(
+		slackSignatureHandler(e.Context.Config.SigningSecret),
+	)
+
+	router.Handle("/events", eventsMiddleware.ThenFunc(e.eventsHandler)).
+		Methods("POST")
+
+	return router
+}
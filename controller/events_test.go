@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/server"
+)
+
+func TestRetryDeletedEvent(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.MustExec(dropSchemas)
+
+	if created, err := datastore.NewEvent(db, "Ev00000001", "T00000001"); err != nil || !created {
+		t.Fatal("Expected the event to be created", created, err)
+	}
+	if created, _ := datastore.NewEvent(db, "Ev00000001", "T00000001"); created {
+		t.Error("Expected the retried event to be skipped")
+	}
+
+	if err := datastore.DeleteEvent(db, "Ev00000001"); err != nil {
+		t.Fatal("Could not delete the event", err)
+	}
+	if created, err := datastore.NewEvent(db, "Ev00000001", "T00000001"); err != nil || !created {
+		t.Error("Expected the retry of the deleted event to be handled", created, err)
+	}
+}
+
+func TestRevokeBotToken(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.MustExec(dropSchemas)
+
+	if _, err := datastore.NewTeam(db, datastore.Team{TeamID: "T00000001", Name: "Team", Domain: "well-a"}); err != nil {
+		t.Fatal("Could not save the team", err)
+	}
+	err := datastore.SaveTeamToken(db, datastore.TeamToken{TeamID: "T00000001", BotToken: []byte("bot"),
+		UserToken: []byte("user")})
+	if err != nil {
+		t.Fatal("Could not save the tokens", err)
+	}
+
+	controller := EventsController{Context: server.Context{Db: db}}
+	event := &slack.Event{Type: slack.TokensRevokedEvent}
+	event.Tokens.Bot = []string{"B00000001"}
+
+	if err = controller.handleEvent("T00000001", event); err != nil {
+		t.Fatal("Could not handle the event", err)
+	}
+
+	token, err := datastore.GetTeamToken(db, "T00000001")
+	if err != nil {
+		t.Fatal("Could not get the tokens", err)
+	}
+	if token.BotToken != nil || !token.Revoked.Valid {
+		t.Error("Bot token should be revoked")
+	}
+	if string(token.UserToken) != "user" {
+		t.Error("User token should be kept", token.UserToken)
+	}
+}
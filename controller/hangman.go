@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"regexp"
+	"strconv"
 
 	"gopkg.in/go-playground/validator.v8"
 
@@ -76,22 +77,48 @@ func (h *HangmanController) hangmanGameHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	guessRegexp, _ := regexp.Compile("^guess ([a-z])$")
+	guessRegexp, _ := regexp.Compile("^guess ([a-z])(?: in #?(\\d+))?$")
+	switchRegexp, _ := regexp.Compile("^switch #?(\\d+)$")
+	puzzleRegexp, _ := regexp.Compile("^puzzle guess ([a-z])$")
+	suggestRegexp, _ := regexp.Compile("^suggest(?: #?(\\d+))?$")
+	analyzeRegexp, _ := regexp.Compile("^analyze(?: #?(\\d+))?$")
 
 	// TODO: Move the user get and create to middleware ?
-	user, err := datastore.GetOrSaveNew(h.Context.Db, input.UserID, input.TeamID, input.Name, input.Domain)
+	user, err := datastore.GetOrSaveNew(h.Context.Db, input.UserID, input.TeamID, input.EnterpriseID,
+		input.Name, input.Domain)
 	if err != nil {
 		log.Fatalln("Could not save or get the user", input.UserID, err)
 	}
-	fmt.Println("User", user)
 
 	switch input.Text {
 	case "start":
 		// Starts the new game
-		message = hngcmd.StartCommand(h.Context.Db, input.UserID)
+		message = hngcmd.StartCommand(h.Context.Db, input.TeamID, input.UserID, input.ChannelID, false)
+	case "start evil":
+		// Starts the hard game where the word keeps changing
+		message = hngcmd.StartCommand(h.Context.Db, input.TeamID, input.UserID, input.ChannelID, true)
 	case "current":
 		// Return the current game state, with information of previous move
-		message = hngcmd.CurrentCommand(h.Context.Db, input.UserID)
+		message = hngcmd.CurrentCommand(h.Context.Db, input.TeamID, input.UserID)
+	case "list":
+		// Show the running games of the user
+		message = hngcmd.ListCommand(h.Context.Db, input.TeamID, input.UserID)
+	case "undo":
+		// Take back the last guess, limited per game
+		message = hngcmd.UndoCommand(h.Context.Db, input.TeamID, input.UserID)
+	case "demo":
+		// The bot plays a game with the best guesses
+		message = hngcmd.DemoCommand(false)
+	case "demo evil":
+		message = hngcmd.DemoCommand(true)
+	case "export":
+		// Show the guesses of the current game as the notation
+		message = hngcmd.ExportCommand(h.Context.Db, input.TeamID, input.UserID)
+	case "puzzle":
+		// Show the puzzle of the day
+		message = hngcmd.PuzzleCommand(h.Context.Db, input.TeamID, input.UserID)
+	case "puzzle results":
+		message = hngcmd.PuzzleResultsCommand(h.Context.Db, input.TeamID)
 	case "ping":
 		// Starts the new game
 		message = hngcmd.PingCommand()
@@ -99,13 +126,38 @@ func (h *HangmanController) hangmanGameHandler(w http.ResponseWriter, r *http.Re
 
 	// Make turn on board and get back the response
 	if guessRegexp.MatchString(input.Text) {
-		// Second element hold character
-		guess := guessRegexp.FindStringSubmatch(input.Text)[1]
+		// Second element hold character, third the optional game
+		matches := guessRegexp.FindStringSubmatch(input.Text)
+		gameID, _ := strconv.Atoi(matches[2])
 
-		message = hngcmd.GuessCommand(h.Context.Db, input.UserID, rune(guess[0]))
+		message = hngcmd.GuessCommand(h.Context.Db, input.TeamID, input.UserID, gameID, rune(matches[1][0]))
 	}
 
-	sendResponse(w, message)
+	if switchRegexp.MatchString(input.Text) {
+		gameID, _ := strconv.Atoi(switchRegexp.FindStringSubmatch(input.Text)[1])
+		message = hngcmd.SwitchCommand(h.Context.Db, input.TeamID, input.UserID, gameID)
+	}
+
+	if puzzleRegexp.MatchString(input.Text) {
+		char := rune(puzzleRegexp.FindStringSubmatch(input.Text)[1][0])
+		message = hngcmd.PuzzleGuessCommand(h.Context.Db, input.TeamID, input.UserID, char)
+	}
+
+	if suggestRegexp.MatchString(input.Text) {
+		gameID, _ := strconv.Atoi(suggestRegexp.FindStringSubmatch(input.Text)[1])
+		message = hngcmd.SuggestCommand(h.Context.Db, input.TeamID, input.UserID, gameID)
+	}
+
+	if analyzeRegexp.MatchString(input.Text) {
+		gameID, _ := strconv.Atoi(analyzeRegexp.FindStringSubmatch(input.Text)[1])
+		message = hngcmd.AnalyzeCommand(h.Context.Db, input.TeamID, input.UserID, gameID)
+	}
+
+	if boardsRegexp.MatchString(input.Text) {
+		message = boardsCommand(h.Context.Db, &user, boardsRegexp.FindStringSubmatch(input.Text)[1])
+	}
+
+	sendGameResponse(w, user, message)
 }
 
 func (h *HangmanController) getImageHandler(w http.ResponseWriter, r *http.Request) {
@@ -125,6 +177,17 @@ func (h *HangmanController) getImageHandler(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+func (h *HangmanController) getExportHandler(w http.ResponseWriter, r *http.Request) {
+	notation, err := hngcmd.GetGameNotation(h.Context.Db, mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Could not get the game notation", 404)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, notation)
+}
+
 // Register creates a new subrouter for the hangman and adds the http handlers
 func (h *HangmanController) Register(router *mux.Router) *mux.Router {
 	decoder.IgnoreUnknownKeys(true)
@@ -132,6 +195,8 @@ func (h *HangmanController) Register(router *mux.Router) *mux.Router {
 
 	tttRouter.HandleFunc("/image/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}", h.getImageHandler).
 		Methods("GET")
+	tttRouter.HandleFunc("/export/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}", h.getExportHandler).
+		Methods("GET")
 
 	hangmanGameMiddleware := alice.New(
 		slackTokenHandler(h.Context.Config.SlackToken),
@@ -1,70 +1,134 @@
 package controller
 
 import (
+	"crypto/subtle"
 	"database/sql"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
-	slackoauth "golang.org/x/oauth2/slack"
-
 	"github.com/gorilla/mux"
 	"github.com/slack-games/slack-client"
 	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/secret"
 	"github.com/slack-games/slack-server/server"
-	"golang.org/x/oauth2"
 )
 
-var oauthConf *oauth2.Config
-var oauthState string
+// Bot scopes to post the game messages and read the users and channels
+var botScopes = []string{"commands", "chat:write", "team:read", "users:read", "channels:read", "groups:read"}
+
+// No user scopes are needed for now, the user token is stored when asked
+var userScopes = []string{}
+
+const (
+	// stateCookie binds the OAuth state to the browser starting the login
+	stateCookie = "oauth_state"
+	stateMaxAge = 10 * time.Minute
+)
+
 var failTemplate, successTemplate *template.Template
 
 type LoginController struct {
 	Context server.Context
 }
 
+// redirectURI returns the callback URL for the configured base path, empty
+// uses the one set in the Slack app settings
+func (l *LoginController) redirectURI() string {
+	if l.Context.Config.BasePath == "" {
+		return ""
+	}
+	return strings.TrimRight(l.Context.Config.BasePath, "/") + "/login/slack/callback"
+}
+
+// setStateCookie stores the signed state, empty state with negative max age
+// removes the cookie. The cookie has no SameSite field, the attribute is
+// appended to the header
+func (l *LoginController) setStateCookie(w http.ResponseWriter, value string, maxAge int) {
+	cookie := &http.Cookie{
+		Name:     stateCookie,
+		Value:    value,
+		Path:     "/login/slack",
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(l.Context.Config.BasePath, "https://"),
+	}
+	w.Header().Add("Set-Cookie", cookie.String()+"; SameSite=Lax")
+}
+
+// verifyState checks the callback state against the signed cookie, the
+// cookie is consumed so the state could be used only once
+func (l *LoginController) verifyState(w http.ResponseWriter, r *http.Request) error {
+	cookie, err := r.Cookie(stateCookie)
+	if err != nil {
+		return err
+	}
+	l.setStateCookie(w, "", -1)
+
+	state, err := l.Context.Signer.Verify(cookie.Value, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(state), []byte(r.FormValue("state"))) != 1 {
+		return errors.New("OAuth state does not match")
+	}
+	return nil
+}
+
 func (l *LoginController) handleSlackLogin(w http.ResponseWriter, r *http.Request) {
-	url := oauthConf.AuthCodeURL(oauthState, oauth2.AccessTypeOnline)
+	state, err := secret.RandomToken(32)
+	if err != nil {
+		log.Printf("Could not create the OAuth state %v\n", err)
+		http.Redirect(w, r, "/login/fail", http.StatusTemporaryRedirect)
+		return
+	}
+
+	signed := l.Context.Signer.Sign(state, time.Now().Add(stateMaxAge))
+	l.setStateCookie(w, signed, int(stateMaxAge.Seconds()))
+
+	url := slack.GetAuthorizeURL(l.Context.Config.ClientID, state, l.redirectURI(), botScopes, userScopes)
 	http.Redirect(w, r, url, http.StatusTemporaryRedirect)
 }
 
 func (l *LoginController) handleSlackCallback(w http.ResponseWriter, r *http.Request) {
-	state := r.FormValue("state")
-	if state != oauthState {
-		fmt.Printf("invalid oauth state, expected '%s', got '%s'\n", oauthState, state)
+	if err := l.verifyState(w, r); err != nil {
+		fmt.Printf("invalid oauth state %s\n", err)
 		http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
 		return
 	}
 
 	code := r.FormValue("code")
-	token, err := oauthConf.Exchange(oauth2.NoContext, code)
+	access, err := slack.NewClient("").OAuthV2Access(l.Context.Config.ClientID, l.Context.Config.SecretKey,
+		code, l.redirectURI())
 	if err != nil {
-		fmt.Printf("oauthConf.Exchange() failed with '%s'\n", err)
+		fmt.Printf("oauth.v2.access failed with '%s'\n", err)
 		http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
 		return
 	}
 
-	log.Println("Received token", token.AccessToken)
+	log.Println("Received tokens for team", access.Team.ID)
 
-	oauthClient := oauthConf.Client(oauth2.NoContext, token)
-	response, err := slack.GetTeamInfo(oauthClient, token)
+	slackTeam, err := slack.NewClient(access.AccessToken).GetTeamInfo()
 	if err != nil {
 		log.Printf("Could not get the team info %v\n", err)
 		http.Redirect(w, r, "/login/fail", http.StatusTemporaryRedirect)
 		return
 	}
 
-	_, err = datastore.GetTeam(l.Context.Db, response.Team.TeamID)
+	_, err = datastore.GetTeam(l.Context.Db, slackTeam.TeamID)
 	if err != nil {
 		// No result found, save team information
 		if err == sql.ErrNoRows {
 			team := datastore.Team{
-				TeamID:      response.Team.TeamID,
-				Name:        response.Team.Name,
-				Domain:      response.Team.Domain,
-				EmailDomain: response.Team.EmailDomain,
+				TeamID:      slackTeam.TeamID,
+				Name:        slackTeam.Name,
+				Domain:      slackTeam.Domain,
+				EmailDomain: slackTeam.EmailDomain,
 				Created:     time.Now(),
 				Modified:    time.Now(),
 			}
@@ -86,10 +150,42 @@ func (l *LoginController) handleSlackCallback(w http.ResponseWriter, r *http.Req
 		}
 	}
 
-	log.Println("Team ", response.Team)
+	if err = l.saveTokens(access); err != nil {
+		log.Printf("Failed to save the team tokens %v\n", err)
+		http.Redirect(w, r, "/login/fail", http.StatusTemporaryRedirect)
+		return
+	}
+
+	log.Println("Team ", slackTeam)
 	http.Redirect(w, r, "/login/success", http.StatusTemporaryRedirect)
 }
 
+// saveTokens encrypts and stores the bot and user tokens of the team
+func (l *LoginController) saveTokens(access *slack.OAuthV2Response) error {
+	botToken, err := l.Context.Secret.Seal([]byte(access.AccessToken))
+	if err != nil {
+		return err
+	}
+
+	var userToken []byte
+	if access.AuthedUser.AccessToken != "" {
+		if userToken, err = l.Context.Secret.Seal([]byte(access.AuthedUser.AccessToken)); err != nil {
+			return err
+		}
+	}
+
+	return datastore.SaveTeamToken(l.Context.Db, datastore.TeamToken{
+		TeamID:    access.Team.ID,
+		AppID:     access.AppID,
+		BotUserID: access.BotUserID,
+		BotToken:  botToken,
+		BotScope:  access.Scope,
+		UserID:    access.AuthedUser.ID,
+		UserToken: userToken,
+		UserScope: access.AuthedUser.Scope,
+	})
+}
+
 func (l *LoginController) handleLoginSuccess(w http.ResponseWriter, r *http.Request) {
 	if err := successTemplate.Execute(w, nil); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -104,16 +200,6 @@ func (l *LoginController) handleLoginFail(w http.ResponseWriter, r *http.Request
 
 // Register creates a new subrouter for the hangman and adds the http handlers
 func (l *LoginController) Register(router *mux.Router) *mux.Router {
-
-	oauthState = randomString(33)
-
-	oauthConf = &oauth2.Config{
-		ClientID:     l.Context.Config.ClientID,
-		ClientSecret: l.Context.Config.SecretKey,
-		Scopes:       []string{"team:read", "commands"},
-		Endpoint:     slackoauth.Endpoint,
-	}
-
 	successTemplate = template.Must(template.ParseFiles(
 		"templates/layout.html",
 		"templates/success.html",
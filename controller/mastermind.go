@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"image/png"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/mastermind"
+	mmscmd "github.com/slack-games/slack-server/mastermind/commands"
+	"github.com/slack-games/slack-server/server"
+)
+
+// MastermindController mastermind controller
+type MastermindController struct {
+	Context server.Context
+}
+
+func (m *MastermindController) isGameCommandHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		command := r.PostFormValue("command")
+
+		if command != "/mastermind" {
+			sendResponse(w, slack.TextOnly("Make sure you have command set to /mastermind"))
+			return
+		}
+
+		log.Println("Valid mastermind game command found")
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *MastermindController) gameHandler(w http.ResponseWriter, r *http.Request) {
+	var message slack.ResponseMessage
+
+	input, err := decodeCommandInput(r, m.Context.Validate)
+	if err != nil {
+		log.Println("Could not parse the game input", err)
+		sendResponse(w, slack.TextOnly("Could not parse the game input"))
+		return
+	}
+
+	startRegexp, _ := regexp.Compile("^start (\\d) (\\d)$")
+	guessRegexp, _ := regexp.Compile("^guess ([a-zA-Z]+)$")
+
+	user, err := datastore.GetOrSaveNew(m.Context.Db, input.UserID, input.TeamID, input.EnterpriseID,
+		input.Name, input.Domain)
+	if err != nil {
+		log.Fatalln("Could not save or get the user", input.UserID, err)
+	}
+
+	switch {
+	case input.Text == "start":
+		message = mmscmd.StartCommand(m.Context.Db, input.TeamID, input.UserID,
+			mastermind.DefaultLength, mastermind.DefaultColors)
+
+	case startRegexp.MatchString(input.Text):
+		matches := startRegexp.FindStringSubmatch(input.Text)
+		length, _ := strconv.Atoi(matches[1])
+		colors, _ := strconv.Atoi(matches[2])
+		message = mmscmd.StartCommand(m.Context.Db, input.TeamID, input.UserID, length, colors)
+
+	case guessRegexp.MatchString(input.Text):
+		guess := guessRegexp.FindStringSubmatch(input.Text)[1]
+		message = mmscmd.GuessCommand(m.Context.Db, input.TeamID, input.UserID, guess)
+
+	case input.Text == "solve":
+		message = mmscmd.SolveCommand(m.Context.Db, input.TeamID, input.UserID)
+
+	case input.Text == "current":
+		message = mmscmd.CurrentCommand(m.Context.Db, input.TeamID, input.UserID)
+
+	case input.Text == "ping":
+		message = mmscmd.PingCommand()
+
+	default:
+		message = mmscmd.HelpCommand()
+	}
+
+	if boardsRegexp.MatchString(input.Text) {
+		message = boardsCommand(m.Context.Db, &user, boardsRegexp.FindStringSubmatch(input.Text)[1])
+	}
+
+	sendGameResponse(w, user, message)
+}
+
+func (m *MastermindController) getImageHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	image, err := mmscmd.GetGameImage(m.Context.Db, id)
+	if err != nil {
+		http.Error(w, "Could not get the state", 404)
+		return
+	}
+
+	err = png.Encode(w, image)
+	if err != nil {
+		http.Error(w, "Could not save the image", 500)
+		return
+	}
+}
+
+// Register creates a new subrouter for the mastermind and adds the http handlers
+func (m *MastermindController) Register(router *mux.Router) *mux.Router {
+	decoder.IgnoreUnknownKeys(true)
+	mmsRouter := router.PathPrefix("/mastermind").Subrouter()
+
+	mmsRouter.HandleFunc("/image/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}", m.getImageHandler).
+		Methods("GET")
+
+	gameMiddleware := alice.New(
+		slackTokenHandler(m.Context.Config.SlackToken),
+		debugFormValues,
+		m.isGameCommandHandler,
+	)
+
+	mmsRouter.Methods("POST").
+		Handler(gameMiddleware.ThenFunc(m.gameHandler))
+
+	return mmsRouter
+}
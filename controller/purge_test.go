@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gorilla/mux"
+	hngcmd "github.com/slack-games/slack-hangman/commands"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/server"
+)
+
+func TestPurgeTeam(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.MustExec(dropSchemas)
+
+	controller := TictactoeController{Context: server.Context{
+		Db:     db,
+		Config: server.Config{SlackToken: "test"},
+	}}
+	router := controller.Register(mux.NewRouter())
+
+	if _, err := tictactoeRequest(router, "start"); err != nil {
+		t.Fatal("Could not start the game", err)
+	}
+	hngcmd.StartCommand(db, "T00000001", "U000000001", "C00000001", false)
+
+	if err := datastore.PurgeTeam(db, "T00000001"); err != nil {
+		t.Fatal("Could not purge the team", err)
+	}
+
+	for _, table := range []string{"ttt.games", "ttt.current_games", "ttt.states", "hng.games", "hng.current_games",
+		"hng.states", "gms.users"} {
+		var count int
+		if err := db.Get(&count, fmt.Sprintf("SELECT count(*) FROM %s WHERE team_id = $1", table), "T00000001"); err != nil {
+			t.Fatal("Could not count the rows", table, err)
+		}
+		if count != 0 {
+			t.Errorf("Expected no rows left in %s, got %d", table, count)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"image/png"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/gorilla/mux"
+	"github.com/justinas/alice"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/reversi"
+	rvscmd "github.com/slack-games/slack-server/reversi/commands"
+	"github.com/slack-games/slack-server/server"
+)
+
+// ReversiController reversi controller
+type ReversiController struct {
+	Context server.Context
+}
+
+func (c *ReversiController) isGameCommandHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		command := r.PostFormValue("command")
+
+		if command != "/reversi" {
+			sendResponse(w, slack.TextOnly("Make sure you have command set to /reversi"))
+			return
+		}
+
+		log.Println("Valid reversi game command found")
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c *ReversiController) gameHandler(w http.ResponseWriter, r *http.Request) {
+	var message slack.ResponseMessage
+
+	input, err := decodeCommandInput(r, c.Context.Validate)
+	if err != nil {
+		log.Println("Could not parse the game input", err)
+		sendResponse(w, slack.TextOnly("Could not parse the game input"))
+		return
+	}
+
+	moveRegexp, _ := regexp.Compile("^move ([a-hA-H][1-8])$")
+
+	user, err := datastore.GetOrSaveNew(c.Context.Db, input.UserID, input.TeamID, input.EnterpriseID,
+		input.Name, input.Domain)
+	if err != nil {
+		log.Fatalln("Could not save or get the user", input.UserID, err)
+	}
+
+	switch input.Text {
+	case "start":
+		message = rvscmd.StartCommand(c.Context.Db, input.TeamID, input.UserID)
+	case "current":
+		message = rvscmd.CurrentCommand(c.Context.Db, input.TeamID, input.UserID)
+	case "ping":
+		message = rvscmd.PingCommand()
+	default:
+		message = rvscmd.HelpCommand()
+	}
+
+	// Make move on board and get back the response
+	if moveRegexp.MatchString(input.Text) {
+		spot, _ := reversi.ParseSpot(moveRegexp.FindStringSubmatch(input.Text)[1])
+		message = rvscmd.MoveCommand(c.Context.Db, input.TeamID, input.UserID, spot)
+	}
+
+	if boardsRegexp.MatchString(input.Text) {
+		message = boardsCommand(c.Context.Db, &user, boardsRegexp.FindStringSubmatch(input.Text)[1])
+	}
+
+	sendGameResponse(w, user, message)
+}
+
+func (c *ReversiController) getImageHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+
+	image, err := rvscmd.GetGameImage(c.Context.Db, id)
+	if err != nil {
+		http.Error(w, "Could not get the state", 404)
+		return
+	}
+
+	err = png.Encode(w, image)
+	if err != nil {
+		http.Error(w, "Could not save the image", 500)
+		return
+	}
+}
+
+// Register creates a new subrouter for the reversi and adds the http handlers
+func (c *ReversiController) Register(router *mux.Router) *mux.Router {
+	decoder.IgnoreUnknownKeys(true)
+	rvsRouter := router.PathPrefix("/reversi").Subrouter()
+
+	rvsRouter.HandleFunc("/image/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}", c.getImageHandler).
+		Methods("GET")
+
+	gameMiddleware := alice.New(
+		slackTokenHandler(c.Context.Config.SlackToken),
+		debugFormValues,
+		c.isGameCommandHandler,
+	)
+
+	rvsRouter.Methods("POST").
+		Handler(gameMiddleware.ThenFunc(c.gameHandler))
+
+	return rvsRouter
+}
@@ -10,9 +10,10 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/justinas/alice"
-	"github.com/riston/slack-server/datastore"
 	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
 	"github.com/slack-games/slack-server/server"
+	"github.com/slack-games/slack-tictactoe"
 	tttcmd "github.com/slack-games/slack-tictactoe/commands"
 )
 
@@ -54,6 +55,45 @@ func (t *TictactoeController) tictactoeImageHandler(w http.ResponseWriter, r *ht
 	}
 }
 
+func (t *TictactoeController) tictactoeAnalysisHandler(w http.ResponseWriter, r *http.Request) {
+	image, err := tttcmd.GetAnalysisImage(t.Context.Db, mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Could not get the state", 404)
+		return
+	}
+
+	if err = png.Encode(w, image); err != nil {
+		http.Error(w, "Could not save the image", 500)
+		return
+	}
+}
+
+func (t *TictactoeController) tictactoePuzzleHandler(w http.ResponseWriter, r *http.Request) {
+	puzzleID, _ := strconv.Atoi(mux.Vars(r)["id"])
+
+	image, err := tttcmd.GetPuzzleImage(t.Context.Db, puzzleID)
+	if err != nil {
+		http.Error(w, "Could not get the puzzle", 404)
+		return
+	}
+
+	if err = png.Encode(w, image); err != nil {
+		http.Error(w, "Could not save the image", 500)
+		return
+	}
+}
+
+func (t *TictactoeController) tictactoeExportHandler(w http.ResponseWriter, r *http.Request) {
+	notation, err := tttcmd.GetGameNotation(t.Context.Db, mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Could not get the game notation", 404)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, notation)
+}
+
 func (t *TictactoeController) gameHandler(w http.ResponseWriter, r *http.Request) {
 	var message slack.ResponseMessage
 
@@ -61,27 +101,60 @@ func (t *TictactoeController) gameHandler(w http.ResponseWriter, r *http.Request
 	text := r.PostFormValue("text")
 	domain := r.PostFormValue("team_domain")
 	teamID := r.PostFormValue("team_id")
+	enterpriseID := r.PostFormValue("enterprise_id")
 	userID := r.PostFormValue("user_id")
+	channelID := r.PostFormValue("channel_id")
 	name := r.PostFormValue("user_name")
 
-	moveRegexp, _ := regexp.Compile("^move (\\d)$")
+	moveRegexp, _ := regexp.Compile("^move (\\d)(?: as ([ox1-9]))?(?: in #?(\\d+))?$")
+	variantRegexp, _ := regexp.Compile("^start (classic|misere|wild|numerical)( channel)?$")
+	ultimateRegexp, _ := regexp.Compile("^move ([1-9]) ([1-9])(?: in #?(\\d+))?$")
+	puzzleRegexp, _ := regexp.Compile("^puzzle ([1-9])$")
+	loadRegexp, _ := regexp.Compile("^load ([1-9 ]+)$")
+	switchRegexp, _ := regexp.Compile("^switch #?(\\d+)$")
+	analyzeRegexp, _ := regexp.Compile("^analyze(?: (\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}))?$")
 
-	user, err := datastore.GetOrSaveNew(t.Context.Db, userID, teamID, name, domain)
+	user, err := datastore.GetOrSaveNew(t.Context.Db, userID, teamID, enterpriseID, name, domain)
 	if err != nil {
 		log.Fatalln("Could not save or get the user", userID, err)
 	}
 
-	fmt.Println("User", user)
-
 	switch text {
 	case "start":
 		// Starts the new game
-		message = tttcmd.StartCommand(t.Context.Db, userID)
+		message = tttcmd.StartCommand(t.Context.Db, nil, teamID, userID, "", false, nil)
+
+	case "start ultimate":
+		// Starts the new game on nine boards
+		message = tttcmd.StartCommand(t.Context.Db, nil, teamID, userID, "", true, nil)
+
+	case "start channel":
+		// Starts the new game everyone in the channel could follow
+		message = tttcmd.StartCommand(t.Context.Db, slackClient(t.Context, teamID), teamID, userID, channelID, false, nil)
 
 	case "current":
 		// Return the current game state, with information of previous move
 		// and also with current whose turn it is
-		message = tttcmd.CurrentCommand(t.Context.Db, userID)
+		message = tttcmd.CurrentCommand(t.Context.Db, teamID, userID, channelID)
+
+	case "list":
+		// Show the running games of the user
+		message = tttcmd.ListCommand(t.Context.Db, teamID, userID)
+
+	case "undo":
+		// Take back the last move
+		message = tttcmd.UndoCommand(t.Context.Db, slackClient(t.Context, teamID), teamID, userID, channelID)
+
+	case "puzzle":
+		// Show the puzzle of the day
+		message = tttcmd.PuzzleCommand(t.Context.Db, teamID, userID)
+
+	case "puzzle results":
+		message = tttcmd.PuzzleResultsCommand(t.Context.Db, teamID)
+
+	case "export":
+		// Show the moves of the current game as the notation
+		message = tttcmd.ExportCommand(t.Context.Db, teamID, userID, channelID)
 
 	case "stats":
 		// Get the players stats
@@ -103,15 +176,78 @@ func (t *TictactoeController) gameHandler(w http.ResponseWriter, r *http.Request
 	// Make turn on board and get back the response
 	if moveRegexp.MatchString(text) {
 
-		// Second element hold number
-		strNumber := moveRegexp.FindStringSubmatch(text)[1]
-		moveTo, _ := strconv.ParseInt(strNumber, 10, 8)
+		// Second element hold number, third the optional symbol and fourth
+		// the optional game
+		matches := moveRegexp.FindStringSubmatch(text)
+		moveTo, _ := strconv.ParseInt(matches[1], 10, 8)
+		gameID, _ := strconv.Atoi(matches[3])
+
+		// The wild game has the symbols o and x, the numerical the numbers
+		var symbol uint8
+		switch matches[2] {
+		case "":
+		case "o":
+			symbol = uint8(tictactoe.MyPlayer)
+		case "x":
+			symbol = uint8(tictactoe.OpponentPlayer)
+		default:
+			symbol = matches[2][0] - '0'
+		}
 
 		// -1 the move number as we use th indexing from 0 to 8 in development
-		message = tttcmd.MoveCommand(t.Context.Db, userID, uint8(moveTo)-1)
+		message = tttcmd.MoveCommand(t.Context.Db, slackClient(t.Context, teamID), teamID, userID, channelID,
+			gameID, uint8(moveTo)-1, symbol)
+	}
+
+	if variantRegexp.MatchString(text) {
+		// Starts the new game with the rules of the variant, in the channel
+		// when asked
+		matches := variantRegexp.FindStringSubmatch(text)
+		variant, _ := tictactoe.GetVariant(matches[1])
+
+		if matches[2] == "" {
+			message = tttcmd.StartCommand(t.Context.Db, nil, teamID, userID, "", false, variant)
+		} else {
+			message = tttcmd.StartCommand(t.Context.Db, slackClient(t.Context, teamID), teamID, userID, channelID,
+				false, variant)
+		}
+	}
+
+	if ultimateRegexp.MatchString(text) {
+		// Second element hold the board, third the cell and fourth the optional game
+		matches := ultimateRegexp.FindStringSubmatch(text)
+		board, _ := strconv.Atoi(matches[1])
+		cell, _ := strconv.Atoi(matches[2])
+		gameID, _ := strconv.Atoi(matches[3])
+
+		message = tttcmd.UltimateMoveCommand(t.Context.Db, slackClient(t.Context, teamID), teamID, userID, channelID,
+			gameID, uint8(board)-1, uint8(cell)-1)
+	}
+
+	if puzzleRegexp.MatchString(text) {
+		spot, _ := strconv.Atoi(puzzleRegexp.FindStringSubmatch(text)[1])
+		message = tttcmd.PuzzleAnswerCommand(t.Context.Db, teamID, userID, uint8(spot)-1)
+	}
+
+	if loadRegexp.MatchString(text) {
+		message = tttcmd.LoadCommand(t.Context.Db, teamID, userID, loadRegexp.FindStringSubmatch(text)[1])
+	}
+
+	if switchRegexp.MatchString(text) {
+		gameID, _ := strconv.Atoi(switchRegexp.FindStringSubmatch(text)[1])
+		message = tttcmd.SwitchCommand(t.Context.Db, teamID, userID, gameID)
+	}
+
+	if analyzeRegexp.MatchString(text) {
+		stateID := analyzeRegexp.FindStringSubmatch(text)[1]
+		message = tttcmd.AnalyzeCommand(t.Context.Db, teamID, userID, channelID, stateID)
+	}
+
+	if boardsRegexp.MatchString(text) {
+		message = boardsCommand(t.Context.Db, &user, boardsRegexp.FindStringSubmatch(text)[1])
 	}
 
-	sendResponse(w, message)
+	sendGameResponse(w, user, message)
 }
 
 // Register adds the tictactoe routes
@@ -119,6 +255,9 @@ func (t *TictactoeController) Register(router *mux.Router) *mux.Router {
 	tttRouter := router.PathPrefix("/tictactoe").Subrouter()
 
 	tttRouter.HandleFunc("/image/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}", t.tictactoeImageHandler)
+	tttRouter.HandleFunc("/puzzle/{id:\\d+}", t.tictactoePuzzleHandler)
+	tttRouter.HandleFunc("/export/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}", t.tictactoeExportHandler)
+	tttRouter.HandleFunc("/analysis/{id:\\w{8}-\\w{4}-\\w{4}-\\w{4}-\\w{12}}", t.tictactoeAnalysisHandler)
 
 	gameMiddleware := alice.New(
 		slackTokenHandler(t.Context.Config.SlackToken),
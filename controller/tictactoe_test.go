@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/server"
+)
+
+const dropSchemas = `DROP SCHEMA IF EXISTS gms, ttt, hng, bts, rvs, chk, mms, dab CASCADE`
+
+// setUpDatabase loads the schema into the DB_TEST database, the test is
+// skipped without it
+func setUpDatabase(t *testing.T) *sqlx.DB {
+	dbURL := os.Getenv("DB_TEST")
+	if dbURL == "" {
+		t.Skip("DB_TEST is not set")
+	}
+
+	db := sqlx.MustConnect("postgres", dbURL)
+	db.MustExec(dropSchemas)
+
+	if _, err := sqlx.LoadFile(db, "../data/deploy.sql"); err != nil {
+		t.Fatal("Failed to load schema", err)
+	}
+	return db
+}
+
+func tictactoeRequest(router *mux.Router, text string) (slack.ResponseMessage, error) {
+	data := url.Values{}
+	data.Set("token", "test")
+	data.Set("command", "/ttt")
+	data.Set("text", text)
+	data.Set("team_id", "T00000001")
+	data.Set("team_domain", "well-a")
+	data.Set("user_id", "U000000001")
+	data.Set("user_name", "Jim")
+
+	r, _ := http.NewRequest("POST", "/tictactoe", bytes.NewBufferString(data.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	var message slack.ResponseMessage
+	err := json.Unmarshal(w.Body.Bytes(), &message)
+	return message, err
+}
+
+func TestConcurrentMoves(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.MustExec(dropSchemas)
+
+	controller := TictactoeController{Context: server.Context{
+		Db:     db,
+		Config: server.Config{SlackToken: "test"},
+	}}
+	router := controller.Register(mux.NewRouter())
+
+	if _, err := tictactoeRequest(router, "start"); err != nil {
+		t.Fatal("Could not start the game", err)
+	}
+
+	var wg sync.WaitGroup
+	for spot := 1; spot <= 9; spot++ {
+		wg.Add(1)
+		go func(spot int) {
+			defer wg.Done()
+			if _, err := tictactoeRequest(router, "move "+strconv.Itoa(spot)); err != nil {
+				t.Error("Could not make the move", spot, err)
+			}
+		}(spot)
+	}
+	wg.Wait()
+
+	var branches int
+	err := db.Get(&branches, `
+		SELECT count(*)
+		FROM ttt.states
+		GROUP BY parent_state_id
+		ORDER BY count(*) DESC LIMIT 1
+	`)
+	if err != nil {
+		t.Fatal("Could not count the states", err)
+	}
+
+	if branches != 1 {
+		t.Errorf("Expected every state to continue from a different state, got %d from one", branches)
+	}
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-games/slack-server/datastore"
+	tttcmd "github.com/slack-games/slack-tictactoe/commands"
+	tttdatastore "github.com/slack-games/slack-tictactoe/datastore"
+)
+
+func TestUndoByNonMover(t *testing.T) {
+	db := setUpDatabase(t)
+	defer db.MustExec(dropSchemas)
+
+	for _, userID := range []string{"U000000001", "U000000002"} {
+		if _, err := datastore.GetOrSaveNew(db, userID, "T00000001", "", userID, "well-a"); err != nil {
+			t.Fatal("Could not save the user", userID, err)
+		}
+	}
+
+	state := tttdatastore.State{
+		TeamID:       "T00000001",
+		State:        "000000000",
+		TurnID:       "U000000001",
+		Mode:         "Turn",
+		FirstUserID:  "U000000001",
+		SecondUserID: "U000000002",
+		Variant:      "classic",
+		ParentID:     "00000000-0000-0000-0000-000000000000",
+		Created:      time.Now(),
+	}
+	game := tttdatastore.Game{TeamID: "T00000001", FirstUserID: "U000000001", SecondUserID: "U000000002",
+		ChannelID: "C00000001"}
+
+	gameID, stateID, err := tttdatastore.NewGame(db, game, state)
+	if err != nil {
+		t.Fatal("Could not create the game", err)
+	}
+
+	// The first player moved, the second is in turn
+	state.GameID, state.ParentID, state.Version = gameID, stateID, 1
+	state.State, state.TurnID = "100000000", "U000000002"
+	if _, err = tttdatastore.NewState(db, state); err != nil {
+		t.Fatal("Could not save the move", err)
+	}
+
+	message := tttcmd.UndoCommand(db, nil, "T00000001", "U000000002", "C00000001")
+	if !strings.Contains(message.Text, "Only the player who made the last move") {
+		t.Error("Opponent should not ask to take back the move", message.Text)
+	}
+
+	var requests int
+	if err = db.Get(&requests, "SELECT count(*) FROM gms.undo_requests"); err != nil {
+		t.Fatal("Could not count the undo requests", err)
+	}
+	if requests != 0 {
+		t.Error("Rejected takeback should not be saved", requests)
+	}
+
+	message = tttcmd.UndoCommand(db, nil, "T00000001", "U000000001", "C00000001")
+	if !strings.Contains(message.Text, "Asked <@U000000002>") {
+		t.Error("Mover should ask the opponent to accept", message.Text)
+	}
+}
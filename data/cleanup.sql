@@ -1,14 +1,44 @@
 
 # General Slack
 DROP TABLE IF EXISTS gms.teams CASCADE;
+DROP TABLE IF EXISTS gms.team_tokens CASCADE;
 DROP TABLE IF EXISTS gms.users CASCADE;
+DROP TABLE IF EXISTS gms.undo_requests CASCADE;
+DROP TABLE IF EXISTS gms.reminders CASCADE;
+DROP TABLE IF EXISTS gms.events CASCADE;
+DROP TABLE IF EXISTS gms.puzzle_attempts CASCADE;
+DROP TABLE IF EXISTS gms.puzzles CASCADE;
 
 DROP SCHEMA IF EXISTS gms CASCADE;
 
 # TicTacToe
+DROP TABLE IF EXISTS ttt.current_games CASCADE;
 DROP TABLE IF EXISTS ttt.states CASCADE;
+DROP TABLE IF EXISTS ttt.games CASCADE;
 DROP SCHEMA IF EXISTS ttt CASCADE;
 
 # Hangman
+DROP TABLE IF EXISTS hng.current_games CASCADE;
 DROP TABLE IF EXISTS hng.states CASCADE;
+DROP TABLE IF EXISTS hng.games CASCADE;
 DROP SCHEMA IF EXISTS hng CASCADE;
+
+# Battleship
+DROP TABLE IF EXISTS bts.states CASCADE;
+DROP SCHEMA IF EXISTS bts CASCADE;
+
+# Reversi
+DROP TABLE IF EXISTS rvs.states CASCADE;
+DROP SCHEMA IF EXISTS rvs CASCADE;
+
+# Checkers
+DROP TABLE IF EXISTS chk.states CASCADE;
+DROP SCHEMA IF EXISTS chk CASCADE;
+
+# Mastermind
+DROP TABLE IF EXISTS mms.states CASCADE;
+DROP SCHEMA IF EXISTS mms CASCADE;
+
+# Dots and boxes
+DROP TABLE IF EXISTS dab.states CASCADE;
+DROP SCHEMA IF EXISTS dab CASCADE;
@@ -7,6 +7,16 @@ CREATE SCHEMA IF NOT EXISTS gms;
 CREATE SCHEMA IF NOT EXISTS ttt;
 -- Hangman game schema
 CREATE SCHEMA IF NOT EXISTS hng;
+-- Battleship game schema
+CREATE SCHEMA IF NOT EXISTS bts;
+-- Reversi game schema
+CREATE SCHEMA IF NOT EXISTS rvs;
+-- Checkers game schema
+CREATE SCHEMA IF NOT EXISTS chk;
+-- Mastermind game schema
+CREATE SCHEMA IF NOT EXISTS mms;
+-- Dots and boxes game schema
+CREATE SCHEMA IF NOT EXISTS dab;
 
 -- DROP TABLE IF EXISTS gms.teams;
 CREATE TABLE IF NOT EXISTS gms.teams (
@@ -19,45 +29,328 @@ CREATE TABLE IF NOT EXISTS gms.teams (
 );
 
 -- DROP TABLE IF EXISTS gms.users;
+-- Users are identified by the team and user ID, the same user could play
+-- from multiple workspaces of the Enterprise Grid
 CREATE TABLE IF NOT EXISTS gms.users (
-    user_id TEXT PRIMARY KEY,
-    team_id TEXT,
+    user_id TEXT NOT NULL,
+    team_id TEXT NOT NULL,
+    enterprise_id TEXT NOT NULL DEFAULT '',
     name TEXT NOT NULL,
     team_domain TEXT NOT NULL,
+    text_boards BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    modified_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+-- OAuth tokens of the installing team, encrypted with the TOKEN_KEY
+CREATE TABLE IF NOT EXISTS gms.team_tokens (
+    team_id TEXT PRIMARY KEY REFERENCES gms.teams (team_id),
+    app_id TEXT NOT NULL DEFAULT '',
+    bot_user_id TEXT NOT NULL DEFAULT '',
+    bot_token BYTEA,
+    bot_scope TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL DEFAULT '',
+    user_token BYTEA,
+    user_scope TEXT NOT NULL DEFAULT '',
+    revoked_at TIMESTAMP,
     created_at TIMESTAMP NOT NULL DEFAULT now(),
     modified_at TIMESTAMP NOT NULL DEFAULT now()
 );
 
+-- Processed Events API events, Slack retries the events without answer
+CREATE TABLE IF NOT EXISTS gms.events (
+    event_id TEXT PRIMARY KEY,
+    team_id TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- Takeback requests waiting for the opponent consent, the state is the one
+-- to take back and could belong to any game schema
+CREATE TABLE IF NOT EXISTS gms.undo_requests (
+    state_id UUID PRIMARY KEY,
+    team_id TEXT NOT NULL,
+    user_id TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+-- Turn reminders sent by the scheduler, at most one per waiting state
+CREATE TABLE IF NOT EXISTS gms.reminders (
+    state_id UUID PRIMARY KEY,
+    team_id TEXT NOT NULL,
+    user_id TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+-- Daily puzzles generated offline, every team gets the same puzzle of the
+-- day. The position and the solution are in the notation of the game
+CREATE TABLE IF NOT EXISTS gms.puzzles (
+    puzzle_id SERIAL PRIMARY KEY,
+    game TEXT NOT NULL,
+    day DATE NOT NULL,
+    position TEXT NOT NULL,
+    solution TEXT NOT NULL,
+    lives SMALLINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (game, day)
+);
+
+-- The single attempt of the user per puzzle, the hangman attempt keeps the
+-- guesses until the word is found or the lives run out
+CREATE TABLE IF NOT EXISTS gms.puzzle_attempts (
+    puzzle_id INTEGER NOT NULL REFERENCES gms.puzzles (puzzle_id),
+    team_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    answer TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'Active',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    modified_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (puzzle_id, team_id, user_id),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
 -- Tic-Tac-Toe
 -- NB! Make sure to remove this
 DROP TYPE IF EXISTS ttt.mode CASCADE;
-CREATE TYPE ttt.mode AS ENUM ('Start', 'Win', 'Draw', 'GameOver', 'Turn', 'Unkown');
+CREATE TYPE ttt.mode AS ENUM ('Start', 'Win', 'Draw', 'GameOver', 'Turn', 'Unkown', 'Forfeit');
+DROP TYPE IF EXISTS ttt.status CASCADE;
+CREATE TYPE ttt.status AS ENUM ('Active', 'Over');
+
+-- Game holds the current state, the states of the game are its history. The
+-- channel games are followed by the spectators in the thread of the message
+CREATE TABLE IF NOT EXISTS ttt.games (
+    game_id SERIAL PRIMARY KEY,
+    team_id TEXT NOT NULL,
+    first_user_id TEXT,
+    second_user_id TEXT,
+    status ttt.status NOT NULL DEFAULT 'Active',
+    state_id UUID,
+    channel_id TEXT NOT NULL DEFAULT '',
+    thread_ts TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    modified_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, first_user_id) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, second_user_id) REFERENCES gms.users (team_id, user_id)
+);
 
 -- DROP TABLE IF EXISTS ttt.states;
 CREATE TABLE IF NOT EXISTS ttt.states (
     state_id UUID PRIMARY KEY UNIQUE DEFAULT gen_random_uuid(),
+    game_id INTEGER NOT NULL REFERENCES ttt.games (game_id),
+    team_id TEXT NOT NULL,
     state TEXT,
-    turn TEXT REFERENCES gms.users (user_id),
+    turn TEXT,
     mode ttt.mode,
-    first_user_id TEXT REFERENCES gms.users (user_id),
-    second_user_id TEXT REFERENCES gms.users (user_id),
+    -- The ultimate game has 81 cells, the board winners and the forced board
+    ultimate BOOLEAN NOT NULL DEFAULT false,
+    -- The rules of the game: classic, misere, wild or numerical
+    variant TEXT NOT NULL DEFAULT 'classic',
+    first_user_id TEXT,
+    second_user_id TEXT,
+    version INTEGER NOT NULL DEFAULT 0,
+    seed BIGINT NOT NULL DEFAULT 0,
     parent_state_id UUID DEFAULT '00000000-0000-0000-0000-000000000000',
-    created_at TIMESTAMP NOT NULL DEFAULT now()
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    -- Concurrent moves from the same state get the same version, only the
+    -- first one is saved
+    UNIQUE (game_id, version),
+    FOREIGN KEY (team_id, turn) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, first_user_id) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, second_user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+-- The game the user is playing when no game ID is given
+CREATE TABLE IF NOT EXISTS ttt.current_games (
+    team_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    game_id INTEGER NOT NULL REFERENCES ttt.games (game_id),
+    PRIMARY KEY (team_id, user_id),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
 );
 
 
 -- Hangman
 DROP TYPE IF EXISTS hng.mode CASCADE;
-CREATE TYPE hng.mode AS ENUM ('Win', 'GameOver', 'Turn', 'Unkown');
+CREATE TYPE hng.mode AS ENUM ('Win', 'GameOver', 'Turn', 'Unkown', 'Forfeit');
+DROP TYPE IF EXISTS hng.status CASCADE;
+CREATE TYPE hng.status AS ENUM ('Active', 'Over');
+
+CREATE TABLE IF NOT EXISTS hng.games (
+    game_id SERIAL PRIMARY KEY,
+    team_id TEXT NOT NULL,
+    user_id TEXT,
+    status hng.status NOT NULL DEFAULT 'Active',
+    state_id UUID,
+    channel_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    modified_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
 
 DROP TABLE IF EXISTS hng.states;
 CREATE TABLE IF NOT EXISTS hng.states (
     state_id UUID PRIMARY KEY UNIQUE DEFAULT gen_random_uuid(),
+    game_id INTEGER NOT NULL REFERENCES hng.games (game_id),
+    team_id TEXT NOT NULL,
     word TEXT NOT NULL,
     guess TEXT NOT NULL,
     current TEXT NOT NULL,
     mode hng.mode,
-    user_id TEXT REFERENCES gms.users (user_id),
+    evil BOOLEAN NOT NULL DEFAULT false,
+    user_id TEXT,
+    undos SMALLINT NOT NULL DEFAULT 0,
+    version INTEGER NOT NULL DEFAULT 0,
+    seed BIGINT NOT NULL DEFAULT 0,
     parent_state_id UUID DEFAULT '00000000-0000-0000-0000-000000000000',
-    created_at TIMESTAMP NOT NULL DEFAULT now()
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (game_id, version),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS hng.current_games (
+    team_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    game_id INTEGER NOT NULL REFERENCES hng.games (game_id),
+    PRIMARY KEY (team_id, user_id),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+
+-- Battleship
+DROP TYPE IF EXISTS bts.mode CASCADE;
+CREATE TYPE bts.mode AS ENUM ('Place', 'Turn', 'Win', 'GameOver', 'Forfeit');
+
+-- Board is stored as 100 chars, empty until the player has placed the ships
+CREATE TABLE IF NOT EXISTS bts.states (
+    state_id UUID PRIMARY KEY UNIQUE DEFAULT gen_random_uuid(),
+    team_id TEXT NOT NULL,
+    first_board TEXT NOT NULL DEFAULT '',
+    second_board TEXT NOT NULL DEFAULT '',
+    turn TEXT,
+    mode bts.mode,
+    first_user_id TEXT,
+    second_user_id TEXT,
+    seed BIGINT NOT NULL DEFAULT 0,
+    parent_state_id UUID DEFAULT '00000000-0000-0000-0000-000000000000',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, turn) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, first_user_id) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, second_user_id) REFERENCES gms.users (team_id, user_id)
 );
+
+-- Game continues only from the latest state, concurrent moves from the same
+-- state would fork the game
+CREATE UNIQUE INDEX IF NOT EXISTS bts_states_parent_idx ON bts.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
+
+
+-- Reversi
+DROP TYPE IF EXISTS rvs.mode CASCADE;
+CREATE TYPE rvs.mode AS ENUM ('Start', 'Win', 'Draw', 'GameOver', 'Turn', 'Unkown', 'Forfeit');
+
+-- Board is stored as 64 chars, last move is the board index or -1
+CREATE TABLE IF NOT EXISTS rvs.states (
+    state_id UUID PRIMARY KEY UNIQUE DEFAULT gen_random_uuid(),
+    team_id TEXT NOT NULL,
+    state TEXT,
+    turn TEXT,
+    mode rvs.mode,
+    first_user_id TEXT,
+    second_user_id TEXT,
+    last_move SMALLINT NOT NULL DEFAULT -1,
+    seed BIGINT NOT NULL DEFAULT 0,
+    parent_state_id UUID DEFAULT '00000000-0000-0000-0000-000000000000',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, turn) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, first_user_id) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, second_user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS rvs_states_parent_idx ON rvs.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
+
+
+-- Checkers
+DROP TYPE IF EXISTS chk.mode CASCADE;
+CREATE TYPE chk.mode AS ENUM ('Start', 'Win', 'Draw', 'GameOver', 'Turn', 'Unkown', 'Forfeit');
+
+-- Board is stored as 32 chars for the dark squares, last move in notation
+CREATE TABLE IF NOT EXISTS chk.states (
+    state_id UUID PRIMARY KEY UNIQUE DEFAULT gen_random_uuid(),
+    team_id TEXT NOT NULL,
+    state TEXT,
+    turn TEXT,
+    mode chk.mode,
+    first_user_id TEXT,
+    second_user_id TEXT,
+    last_move TEXT NOT NULL DEFAULT '',
+    quiet_moves INTEGER NOT NULL DEFAULT 0,
+    seed BIGINT NOT NULL DEFAULT 0,
+    parent_state_id UUID DEFAULT '00000000-0000-0000-0000-000000000000',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, turn) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, first_user_id) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, second_user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS chk_states_parent_idx ON chk.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
+
+
+-- Mastermind
+DROP TYPE IF EXISTS mms.mode CASCADE;
+CREATE TYPE mms.mode AS ENUM ('Win', 'GameOver', 'Turn', 'Unkown', 'Forfeit');
+
+-- Guesses are stored as comma separated list
+CREATE TABLE IF NOT EXISTS mms.states (
+    state_id UUID PRIMARY KEY UNIQUE DEFAULT gen_random_uuid(),
+    team_id TEXT NOT NULL,
+    code TEXT NOT NULL,
+    guesses TEXT NOT NULL,
+    code_length SMALLINT NOT NULL DEFAULT 4,
+    colors SMALLINT NOT NULL DEFAULT 6,
+    mode mms.mode,
+    user_id TEXT,
+    seed BIGINT NOT NULL DEFAULT 0,
+    parent_state_id UUID DEFAULT '00000000-0000-0000-0000-000000000000',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS mms_states_parent_idx ON mms.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
+
+
+-- Dots and boxes
+DROP TYPE IF EXISTS dab.mode CASCADE;
+CREATE TYPE dab.mode AS ENUM ('Win', 'Draw', 'GameOver', 'Turn', 'Unkown', 'Forfeit');
+
+-- Edges hold the horizontal and then vertical lines row by row, the value is
+-- the player number who drew the line. Third and fourth players are optional
+CREATE TABLE IF NOT EXISTS dab.states (
+    state_id UUID PRIMARY KEY UNIQUE DEFAULT gen_random_uuid(),
+    team_id TEXT NOT NULL,
+    width SMALLINT NOT NULL DEFAULT 4,
+    height SMALLINT NOT NULL DEFAULT 4,
+    edges TEXT NOT NULL,
+    boxes TEXT NOT NULL,
+    turn TEXT,
+    mode dab.mode,
+    first_user_id TEXT,
+    second_user_id TEXT,
+    third_user_id TEXT,
+    fourth_user_id TEXT,
+    channel_id TEXT NOT NULL,
+    parent_state_id UUID DEFAULT '00000000-0000-0000-0000-000000000000',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, turn) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, first_user_id) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, second_user_id) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, third_user_id) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, fourth_user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS dab_states_parent_idx ON dab.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
@@ -0,0 +1,9 @@
+-- Adds the spectator thread of the channel games, before the channel was
+-- only recorded so the old games are not bound to any channel
+
+BEGIN;
+
+ALTER TABLE ttt.games ADD COLUMN IF NOT EXISTS thread_ts TEXT NOT NULL DEFAULT '';
+UPDATE ttt.games SET channel_id = '';
+
+COMMIT;
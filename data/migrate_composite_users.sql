@@ -0,0 +1,58 @@
+-- Migrates the existing database to the users identified by the team and
+-- user ID. The new deploys get the same schema from deploy.sql
+
+BEGIN;
+
+ALTER TABLE gms.users ADD COLUMN IF NOT EXISTS enterprise_id TEXT NOT NULL DEFAULT '';
+
+-- Removes also the old foreign keys of the game states
+ALTER TABLE gms.users DROP CONSTRAINT IF EXISTS users_pkey CASCADE;
+ALTER TABLE gms.users ALTER COLUMN team_id SET NOT NULL;
+ALTER TABLE gms.users ADD PRIMARY KEY (team_id, user_id);
+
+-- Every team has its own bot user
+INSERT INTO gms.users (user_id, team_id, name, team_domain)
+    SELECT DISTINCT ON (team_id) 'U000000000', team_id, 'AI Bill', team_domain
+    FROM gms.users
+ON CONFLICT DO NOTHING;
+
+DO $$
+DECLARE
+    tables TEXT[][] := ARRAY[
+        ['ttt.states', 'turn,first_user_id,second_user_id'],
+        ['hng.states', 'user_id'],
+        ['bts.states', 'turn,first_user_id,second_user_id'],
+        ['rvs.states', 'turn,first_user_id,second_user_id'],
+        ['chk.states', 'turn,first_user_id,second_user_id'],
+        ['mms.states', 'user_id'],
+        ['dab.states', 'turn,first_user_id,second_user_id,third_user_id,fourth_user_id'],
+        ['gms.undo_requests', 'user_id'],
+        ['gms.reminders', 'user_id']
+    ];
+    columns TEXT[];
+    i INT;
+    col TEXT;
+BEGIN
+    FOR i IN 1 .. array_length(tables, 1) LOOP
+        columns := string_to_array(tables[i][2], ',');
+
+        EXECUTE format('ALTER TABLE %s ADD COLUMN IF NOT EXISTS team_id TEXT', tables[i][1]);
+
+        -- The user IDs were unique before, the team is taken from any human player
+        EXECUTE format(
+            'UPDATE %s s SET team_id = u.team_id FROM gms.users u
+             WHERE u.user_id <> ''U000000000'' AND u.user_id = ANY(ARRAY[%s])',
+            tables[i][1],
+            (SELECT string_agg('s.' || c, ',') FROM unnest(columns) c));
+
+        EXECUTE format('ALTER TABLE %s ALTER COLUMN team_id SET NOT NULL', tables[i][1]);
+
+        FOREACH col IN ARRAY columns LOOP
+            EXECUTE format(
+                'ALTER TABLE %s ADD FOREIGN KEY (team_id, %s) REFERENCES gms.users (team_id, user_id)',
+                tables[i][1], col);
+        END LOOP;
+    END LOOP;
+END $$;
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Adds the evil hangman games where the word changes on every guess
+
+BEGIN;
+
+ALTER TABLE hng.states ADD COLUMN IF NOT EXISTS evil BOOLEAN NOT NULL DEFAULT false;
+
+COMMIT;
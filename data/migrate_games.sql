@@ -0,0 +1,132 @@
+-- Migrates the tic-tac-toe and hangman states into games. Every state chain
+-- starting from the empty parent is one game, the newest state of the chain
+-- is the current state. The new deploys get the same schema from deploy.sql
+
+BEGIN;
+
+-- Tic-Tac-Toe
+DROP TYPE IF EXISTS ttt.status CASCADE;
+CREATE TYPE ttt.status AS ENUM ('Active', 'Over');
+
+CREATE TABLE IF NOT EXISTS ttt.games (
+    game_id SERIAL PRIMARY KEY,
+    team_id TEXT NOT NULL,
+    first_user_id TEXT,
+    second_user_id TEXT,
+    status ttt.status NOT NULL DEFAULT 'Active',
+    state_id UUID,
+    channel_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    modified_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, first_user_id) REFERENCES gms.users (team_id, user_id),
+    FOREIGN KEY (team_id, second_user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+ALTER TABLE ttt.states ADD COLUMN IF NOT EXISTS game_id INTEGER REFERENCES ttt.games (game_id);
+
+-- The root state is kept temporarily in the state_id
+INSERT INTO ttt.games (team_id, first_user_id, second_user_id, state_id, created_at, modified_at)
+    SELECT team_id, first_user_id, second_user_id, state_id, created_at, created_at
+    FROM ttt.states
+    WHERE parent_state_id = '00000000-0000-0000-0000-000000000000';
+
+WITH RECURSIVE chain AS (
+    SELECT g.game_id, g.state_id
+    FROM ttt.games g
+    UNION ALL
+    SELECT c.game_id, s.state_id
+    FROM chain c
+    JOIN ttt.states s ON s.parent_state_id = c.state_id
+)
+UPDATE ttt.states s SET game_id = c.game_id FROM chain c WHERE s.state_id = c.state_id;
+
+ALTER TABLE ttt.states ALTER COLUMN game_id SET NOT NULL;
+
+UPDATE ttt.games g
+SET
+    state_id = s.state_id,
+    modified_at = s.created_at,
+    status = CAST(CASE WHEN s.mode IN ('Start', 'Turn') THEN 'Active' ELSE 'Over' END AS ttt.status)
+FROM (
+    SELECT DISTINCT ON (game_id) game_id, state_id, mode, created_at
+    FROM ttt.states
+    ORDER BY game_id, created_at DESC
+) s
+WHERE g.game_id = s.game_id;
+
+CREATE TABLE IF NOT EXISTS ttt.current_games (
+    team_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    game_id INTEGER NOT NULL REFERENCES ttt.games (game_id),
+    PRIMARY KEY (team_id, user_id),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+-- Before the users had only their newest game
+INSERT INTO ttt.current_games (team_id, user_id, game_id)
+    SELECT DISTINCT ON (g.team_id, p.user_id) g.team_id, p.user_id, g.game_id
+    FROM ttt.games g, LATERAL (VALUES (g.first_user_id), (g.second_user_id)) p (user_id)
+    WHERE p.user_id <> 'U000000000'
+    ORDER BY g.team_id, p.user_id, g.modified_at DESC;
+
+-- Hangman
+DROP TYPE IF EXISTS hng.status CASCADE;
+CREATE TYPE hng.status AS ENUM ('Active', 'Over');
+
+CREATE TABLE IF NOT EXISTS hng.games (
+    game_id SERIAL PRIMARY KEY,
+    team_id TEXT NOT NULL,
+    user_id TEXT,
+    status hng.status NOT NULL DEFAULT 'Active',
+    state_id UUID,
+    channel_id TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    modified_at TIMESTAMP NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+ALTER TABLE hng.states ADD COLUMN IF NOT EXISTS game_id INTEGER REFERENCES hng.games (game_id);
+
+INSERT INTO hng.games (team_id, user_id, state_id, created_at, modified_at)
+    SELECT team_id, user_id, state_id, created_at, created_at
+    FROM hng.states
+    WHERE parent_state_id = '00000000-0000-0000-0000-000000000000';
+
+WITH RECURSIVE chain AS (
+    SELECT g.game_id, g.state_id
+    FROM hng.games g
+    UNION ALL
+    SELECT c.game_id, s.state_id
+    FROM chain c
+    JOIN hng.states s ON s.parent_state_id = c.state_id
+)
+UPDATE hng.states s SET game_id = c.game_id FROM chain c WHERE s.state_id = c.state_id;
+
+ALTER TABLE hng.states ALTER COLUMN game_id SET NOT NULL;
+
+UPDATE hng.games g
+SET
+    state_id = s.state_id,
+    modified_at = s.created_at,
+    status = CAST(CASE WHEN s.mode = 'Turn' THEN 'Active' ELSE 'Over' END AS hng.status)
+FROM (
+    SELECT DISTINCT ON (game_id) game_id, state_id, mode, created_at
+    FROM hng.states
+    ORDER BY game_id, created_at DESC
+) s
+WHERE g.game_id = s.game_id;
+
+CREATE TABLE IF NOT EXISTS hng.current_games (
+    team_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    game_id INTEGER NOT NULL REFERENCES hng.games (game_id),
+    PRIMARY KEY (team_id, user_id),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+INSERT INTO hng.current_games (team_id, user_id, game_id)
+    SELECT DISTINCT ON (team_id, user_id) team_id, user_id, game_id
+    FROM hng.games
+    ORDER BY team_id, user_id, modified_at DESC;
+
+COMMIT;
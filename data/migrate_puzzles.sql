@@ -0,0 +1,28 @@
+-- Adds the daily puzzles and the attempts of the users
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS gms.puzzles (
+    puzzle_id SERIAL PRIMARY KEY,
+    game TEXT NOT NULL,
+    day DATE NOT NULL,
+    position TEXT NOT NULL,
+    solution TEXT NOT NULL,
+    lives SMALLINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    UNIQUE (game, day)
+);
+
+CREATE TABLE IF NOT EXISTS gms.puzzle_attempts (
+    puzzle_id INTEGER NOT NULL REFERENCES gms.puzzles (puzzle_id),
+    team_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    answer TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'Active',
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    modified_at TIMESTAMP NOT NULL DEFAULT now(),
+    PRIMARY KEY (puzzle_id, team_id, user_id),
+    FOREIGN KEY (team_id, user_id) REFERENCES gms.users (team_id, user_id)
+);
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Adds the random seeds of the games, the games started before have the
+-- seed 0 and could not be replayed
+
+BEGIN;
+
+ALTER TABLE ttt.states ADD COLUMN IF NOT EXISTS seed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE hng.states ADD COLUMN IF NOT EXISTS seed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE bts.states ADD COLUMN IF NOT EXISTS seed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE rvs.states ADD COLUMN IF NOT EXISTS seed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE chk.states ADD COLUMN IF NOT EXISTS seed BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE mms.states ADD COLUMN IF NOT EXISTS seed BIGINT NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,42 @@
+-- Adds the uniqueness of the game states so the concurrent moves can not
+-- fork the games. The versions of the existing states follow the history
+-- order of the game
+
+BEGIN;
+
+ALTER TABLE ttt.states ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 0;
+
+UPDATE ttt.states s SET version = v.version
+FROM (
+    SELECT state_id, ROW_NUMBER() OVER (PARTITION BY game_id ORDER BY created_at, state_id) - 1 AS version
+    FROM ttt.states
+) v
+WHERE s.state_id = v.state_id;
+
+ALTER TABLE ttt.states ADD UNIQUE (game_id, version);
+
+ALTER TABLE hng.states ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 0;
+
+UPDATE hng.states s SET version = v.version
+FROM (
+    SELECT state_id, ROW_NUMBER() OVER (PARTITION BY game_id ORDER BY created_at, state_id) - 1 AS version
+    FROM hng.states
+) v
+WHERE s.state_id = v.state_id;
+
+ALTER TABLE hng.states ADD UNIQUE (game_id, version);
+
+-- Fails when some of the games has already forked, the extra states have to
+-- be removed first
+CREATE UNIQUE INDEX IF NOT EXISTS bts_states_parent_idx ON bts.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
+CREATE UNIQUE INDEX IF NOT EXISTS rvs_states_parent_idx ON rvs.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
+CREATE UNIQUE INDEX IF NOT EXISTS chk_states_parent_idx ON chk.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
+CREATE UNIQUE INDEX IF NOT EXISTS mms_states_parent_idx ON mms.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
+CREATE UNIQUE INDEX IF NOT EXISTS dab_states_parent_idx ON dab.states (parent_state_id)
+    WHERE parent_state_id <> '00000000-0000-0000-0000-000000000000';
+
+COMMIT;
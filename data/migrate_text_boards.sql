@@ -0,0 +1,7 @@
+-- Adds the user preference for the text boards instead of the images
+
+BEGIN;
+
+ALTER TABLE gms.users ADD COLUMN IF NOT EXISTS text_boards BOOLEAN NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Adds the misere, wild and numerical rule variants of tic-tac-toe
+
+BEGIN;
+
+ALTER TABLE ttt.states ADD COLUMN IF NOT EXISTS variant TEXT NOT NULL DEFAULT 'classic';
+
+COMMIT;
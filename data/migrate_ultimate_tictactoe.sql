@@ -0,0 +1,7 @@
+-- Adds the ultimate tic-tac-toe games played on nine boards
+
+BEGIN;
+
+ALTER TABLE ttt.states ADD COLUMN IF NOT EXISTS ultimate BOOLEAN NOT NULL DEFAULT false;
+
+COMMIT;
@@ -0,0 +1,24 @@
+package datastore
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the Postgres error code of the unique constraints
+const uniqueViolation = "23505"
+
+// ErrConflict is returned when another request saved the next game state
+// first, both read the same state and saving the later one would fork the
+// game
+var ErrConflict = errors.New("Game state was changed meanwhile")
+
+// CheckConflict converts the unique violation of the game state into the
+// ErrConflict, the other errors are returned as is
+func CheckConflict(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return ErrConflict
+	}
+	return err
+}
@@ -0,0 +1,27 @@
+package datastore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestCheckConflict(t *testing.T) {
+	if err := CheckConflict(&pq.Error{Code: "23505"}); err != ErrConflict {
+		t.Error("Unique violation should be a conflict", err)
+	}
+
+	if err := CheckConflict(&pq.Error{Code: "23503"}); err == ErrConflict {
+		t.Error("Foreign key violation is not a conflict")
+	}
+
+	if err := CheckConflict(nil); err != nil {
+		t.Error("No error should stay nil", err)
+	}
+
+	other := errors.New("other")
+	if err := CheckConflict(other); err != other {
+		t.Error("Other errors should be returned as is", err)
+	}
+}
@@ -0,0 +1,32 @@
+package datastore
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// NewEvent marks the event processed, returns false when the event has
+// already been seen, Slack retries the events it did not get answer for
+func NewEvent(db *sqlx.DB, eventID, teamID string) (bool, error) {
+	sql := `
+		INSERT INTO gms.events
+			(event_id, team_id)
+		VALUES
+			($1, $2)
+		ON CONFLICT (event_id) DO NOTHING
+	`
+
+	result, err := db.Exec(sql, eventID, teamID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
+
+// DeleteEvent forgets the event, so the retry of the event which could not
+// be handled is processed again
+func DeleteEvent(db *sqlx.DB, eventID string) error {
+	_, err := db.Exec(`DELETE FROM gms.events WHERE event_id = $1`, eventID)
+	return err
+}
@@ -0,0 +1,45 @@
+package datastore
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Tables with the team data, the states have to be removed before the games
+// and the games before the users they reference
+var teamTables = []string{
+	"ttt.states",
+	"ttt.current_games",
+	"ttt.games",
+	"hng.states",
+	"hng.current_games",
+	"hng.games",
+	"bts.states",
+	"rvs.states",
+	"chk.states",
+	"mms.states",
+	"dab.states",
+	"gms.undo_requests",
+	"gms.reminders",
+	"gms.puzzle_attempts",
+	"gms.users",
+	"gms.team_tokens",
+	"gms.teams",
+}
+
+// PurgeTeam removes all the team data after the app has been uninstalled
+func PurgeTeam(db *sqlx.DB, teamID string) error {
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range teamTables {
+		if _, err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE team_id = $1", table), teamID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
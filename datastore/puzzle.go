@@ -0,0 +1,152 @@
+package datastore
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// TictactoePuzzle is the win in two tic-tac-toe position
+	TictactoePuzzle = "tictactoe"
+	// HangmanPuzzle is the partially revealed hangman word
+	HangmanPuzzle = "hangman"
+)
+
+const (
+	// ActiveAttempt is the hangman puzzle still guessed
+	ActiveAttempt = "Active"
+	// SolvedAttempt is the solved puzzle
+	SolvedAttempt = "Solved"
+	// FailedAttempt is the puzzle the user could not solve
+	FailedAttempt = "Failed"
+)
+
+// Puzzle is the puzzle of the day, every team gets the same puzzle. The
+// position and the solution are in the notation of the game
+type Puzzle struct {
+	PuzzleID int       `db:"puzzle_id"`
+	Game     string    `db:"game"`
+	Day      time.Time `db:"day"`
+	Position string    `db:"position"`
+	Solution string    `db:"solution"`
+	Lives    int       `db:"lives"`
+	Created  time.Time `db:"created_at"`
+}
+
+// PuzzleAttempt is the single attempt of the user to solve the puzzle
+type PuzzleAttempt struct {
+	PuzzleID int       `db:"puzzle_id"`
+	TeamID   string    `db:"team_id"`
+	UserID   string    `db:"user_id"`
+	Answer   string    `db:"answer"`
+	Status   string    `db:"status"`
+	Created  time.Time `db:"created_at"`
+	Modified time.Time `db:"modified_at"`
+}
+
+// PuzzleDay returns the day of the puzzle at the time, the days change at
+// midnight UTC for every team
+func PuzzleDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// GetDailyPuzzle returns the puzzle of the game for the day
+func GetDailyPuzzle(db *sqlx.DB, game, day string) (Puzzle, error) {
+	puzzle := Puzzle{}
+
+	sql := `
+		SELECT *
+		FROM gms.puzzles
+		WHERE game = $1 AND day = CAST($2 AS DATE)
+		LIMIT 1
+	`
+
+	err := db.Get(&puzzle, sql, game, day)
+	return puzzle, err
+}
+
+// GetPuzzle returns the puzzle by ID
+func GetPuzzle(db *sqlx.DB, puzzleID int) (Puzzle, error) {
+	puzzle := Puzzle{}
+
+	err := db.Get(&puzzle, `SELECT * FROM gms.puzzles WHERE puzzle_id = $1 LIMIT 1`, puzzleID)
+	return puzzle, err
+}
+
+// NewPuzzle saves the puzzle of the day, returns false when the day already
+// has the puzzle so the generated puzzles are never changed
+func NewPuzzle(db *sqlx.DB, puzzle Puzzle) (bool, error) {
+	sql := `
+		INSERT INTO gms.puzzles
+			(game, day, position, solution, lives)
+		VALUES
+			(:game, :day, :position, :solution, :lives)
+		ON CONFLICT (game, day) DO NOTHING
+	`
+
+	result, err := db.NamedExec(sql, puzzle)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
+
+// GetPuzzleAttempt returns the attempt of the user
+func GetPuzzleAttempt(db *sqlx.DB, puzzleID int, teamID, userID string) (PuzzleAttempt, error) {
+	attempt := PuzzleAttempt{}
+
+	sql := `
+		SELECT *
+		FROM gms.puzzle_attempts
+		WHERE puzzle_id = $1 AND team_id = $2 AND user_id = $3
+		LIMIT 1
+	`
+
+	err := db.Get(&attempt, sql, puzzleID, teamID, userID)
+	return attempt, err
+}
+
+// SavePuzzleAttempt saves the attempt, the attempt could be changed only
+// while it's active. Returns false when the puzzle was already solved or
+// failed so every user gets only the one attempt
+func SavePuzzleAttempt(db *sqlx.DB, attempt PuzzleAttempt) (bool, error) {
+	sql := `
+		INSERT INTO gms.puzzle_attempts
+			(puzzle_id, team_id, user_id, answer, status)
+		VALUES
+			(:puzzle_id, :team_id, :user_id, :answer, :status)
+		ON CONFLICT (puzzle_id, team_id, user_id) DO UPDATE
+		SET
+			answer = EXCLUDED.answer,
+			status = EXCLUDED.status,
+			modified_at = now()
+		WHERE gms.puzzle_attempts.status = 'Active'
+	`
+
+	result, err := db.NamedExec(sql, attempt)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
+
+// GetPuzzleAttempts returns the attempts of the team, the first finished
+// first
+func GetPuzzleAttempts(db *sqlx.DB, puzzleID int, teamID string) ([]PuzzleAttempt, error) {
+	attempts := []PuzzleAttempt{}
+
+	sql := `
+		SELECT *
+		FROM gms.puzzle_attempts
+		WHERE puzzle_id = $1 AND team_id = $2
+		ORDER BY modified_at
+	`
+
+	err := db.Select(&attempts, sql, puzzleID, teamID)
+	return attempts, err
+}
@@ -0,0 +1,30 @@
+package datastore
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+// ForfeitMode is the mode of the state ending the game by forfeit, the
+// player in turn did not move in time. The game is over like with the
+// GameOver mode, but the forfeit is told apart from the lost game
+const ForfeitMode = "Forfeit"
+
+// NewReminder marks the reminder sent for the state, returns false when the
+// reminder has already been sent
+func NewReminder(db *sqlx.DB, stateID, teamID, userID string) (bool, error) {
+	sql := `
+		INSERT INTO gms.reminders
+			(state_id, team_id, user_id)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (state_id) DO NOTHING
+	`
+
+	result, err := db.Exec(sql, stateID, teamID, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	return rows == 1, err
+}
@@ -0,0 +1,19 @@
+package datastore
+
+import (
+	"math/rand"
+	"time"
+)
+
+// NewSeed returns the seed of the new game, the seed is stored with the game
+// states so the game could be replayed exactly. Replaced in the local
+// sessions for the reproducible games
+var NewSeed = func() int64 {
+	return time.Now().UnixNano()
+}
+
+// Rand returns the random source for the step of the game, the same seed and
+// step give always the same numbers
+func Rand(seed int64, step int) *rand.Rand {
+	return rand.New(rand.NewSource(seed + int64(step)))
+}
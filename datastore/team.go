@@ -39,3 +39,12 @@ func NewTeam(db *sqlx.DB, team Team) (sql.Result, error) {
 	`
 	return db.NamedExec(sql, team)
 }
+
+func UpdateTeamName(db *sqlx.DB, ID, name string) (sql.Result, error) {
+	sql := `
+		UPDATE gms.teams
+		SET name = $2, modified_at = now()
+		WHERE team_id = $1
+	`
+	return db.Exec(sql, ID, name)
+}
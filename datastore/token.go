@@ -0,0 +1,109 @@
+package datastore
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// TeamToken holds the OAuth tokens of the installing team, the tokens are
+// stored encrypted
+type TeamToken struct {
+	TeamID    string      `db:"team_id"`
+	AppID     string      `db:"app_id"`
+	BotUserID string      `db:"bot_user_id"`
+	BotToken  []byte      `db:"bot_token"`
+	BotScope  string      `db:"bot_scope"`
+	UserID    string      `db:"user_id"`
+	UserToken []byte      `db:"user_token"`
+	UserScope string      `db:"user_scope"`
+	Revoked   pq.NullTime `db:"revoked_at"`
+	Created   time.Time   `db:"created_at"`
+	Modified  time.Time   `db:"modified_at"`
+}
+
+func GetTeamToken(db *sqlx.DB, teamID string) (TeamToken, error) {
+	token := TeamToken{}
+
+	sql := `
+		SELECT *
+		FROM gms.team_tokens
+		WHERE team_id = $1
+		LIMIT 1
+	`
+
+	err := db.Get(&token, sql, teamID)
+	return token, err
+}
+
+// SaveTeamToken stores the tokens, reinstalling the app replaces the old
+// tokens and clears the revoke
+func SaveTeamToken(db *sqlx.DB, token TeamToken) error {
+	sql := `
+		INSERT INTO gms.team_tokens
+			(team_id, app_id, bot_user_id, bot_token, bot_scope, user_id, user_token, user_scope)
+		VALUES
+			(:team_id, :app_id, :bot_user_id, :bot_token, :bot_scope, :user_id, :user_token, :user_scope)
+		ON CONFLICT (team_id) DO UPDATE SET
+			app_id = EXCLUDED.app_id,
+			bot_user_id = EXCLUDED.bot_user_id,
+			bot_token = EXCLUDED.bot_token,
+			bot_scope = EXCLUDED.bot_scope,
+			user_id = EXCLUDED.user_id,
+			user_token = EXCLUDED.user_token,
+			user_scope = EXCLUDED.user_scope,
+			revoked_at = NULL,
+			modified_at = now()
+	`
+
+	_, err := db.NamedExec(sql, token)
+	return err
+}
+
+// RevokeTeamToken removes the tokens after the team has revoked them or
+// uninstalled the app
+func RevokeTeamToken(db *sqlx.DB, teamID string) error {
+	sql := `
+		UPDATE gms.team_tokens
+		SET
+			bot_token = NULL,
+			user_token = NULL,
+			revoked_at = now(),
+			modified_at = now()
+		WHERE team_id = $1
+	`
+
+	_, err := db.Exec(sql, teamID)
+	return err
+}
+
+// RevokeBotToken removes only the bot token, the team could not be posted to
+// but the user token is kept
+func RevokeBotToken(db *sqlx.DB, teamID string) error {
+	sql := `
+		UPDATE gms.team_tokens
+		SET
+			bot_token = NULL,
+			revoked_at = now(),
+			modified_at = now()
+		WHERE team_id = $1
+	`
+
+	_, err := db.Exec(sql, teamID)
+	return err
+}
+
+// RevokeUserToken removes only the user token, the bot could still post
+func RevokeUserToken(db *sqlx.DB, teamID string) error {
+	sql := `
+		UPDATE gms.team_tokens
+		SET
+			user_token = NULL,
+			modified_at = now()
+		WHERE team_id = $1
+	`
+
+	_, err := db.Exec(sql, teamID)
+	return err
+}
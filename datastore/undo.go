@@ -0,0 +1,42 @@
+package datastore
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// UndoRequest is the takeback asked by the player, in games against another
+// player the opponent has to accept it before the move is taken back
+type UndoRequest struct {
+	StateID string    `db:"state_id"`
+	TeamID  string    `db:"team_id"`
+	UserID  string    `db:"user_id"`
+	Created time.Time `db:"created_at"`
+}
+
+func GetUndoRequest(db *sqlx.DB, stateID string) (UndoRequest, error) {
+	request := UndoRequest{}
+
+	sql := `
+		SELECT *
+		FROM gms.undo_requests
+		WHERE state_id = $1
+		LIMIT 1
+	`
+
+	err := db.Get(&request, sql, stateID)
+	return request, err
+}
+
+func NewUndoRequest(db *sqlx.DB, stateID, teamID, userID string) error {
+	sql := `
+		INSERT INTO gms.undo_requests
+			(state_id, team_id, user_id)
+		VALUES
+			($1, $2, $3)
+	`
+
+	_, err := db.Exec(sql, stateID, teamID, userID)
+	return err
+}
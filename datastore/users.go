@@ -8,39 +8,84 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// BotUserID is the bot player, every team has its own bot user
+const BotUserID = "U000000000"
+
+// BotName is the bot user name
+const BotName = "AI Bill"
+
+// User is identified by the team and user ID
 type User struct {
-	UserID     string    `db:"user_id"`
-	TeamID     string    `db:"team_id"`
-	Name       string    `db:"name"`
-	TeamDomain string    `db:"team_domain"`
-	Created    time.Time `db:"created_at"`
-	Modified   time.Time `db:"modified_at"`
+	UserID       string    `db:"user_id"`
+	TeamID       string    `db:"team_id"`
+	EnterpriseID string    `db:"enterprise_id"`
+	Name         string    `db:"name"`
+	TeamDomain   string    `db:"team_domain"`
+	TextBoards   bool      `db:"text_boards"`
+	Created      time.Time `db:"created_at"`
+	Modified     time.Time `db:"modified_at"`
+}
+
+func GetUser(db *sqlx.DB, teamID, userID string) (User, error) {
+	user := User{}
+
+	sql := `
+		SELECT *
+		FROM gms.users
+		WHERE team_id = $1 AND user_id = $2
+		LIMIT 1
+	`
+
+	err := db.Get(&user, sql, teamID, userID)
+	return user, err
 }
 
-func GetUser(db *sqlx.DB, ID string) (User, error) {
+// GetUserByName finds the team user by Slack user name
+func GetUserByName(db *sqlx.DB, teamID, name string) (User, error) {
 	user := User{}
 
 	sql := `
 		SELECT *
 		FROM gms.users
-		WHERE user_id = $1
+		WHERE team_id = $1 AND name = $2
 		LIMIT 1
 	`
 
-	err := db.Get(&user, sql, ID)
+	err := db.Get(&user, sql, teamID, name)
 	return user, err
 }
 
 func NewUser(db *sqlx.DB, user User) (sql.Result, error) {
 	sql := `
 		INSERT INTO gms.users
-			(user_id, team_id, name, team_domain)
+			(user_id, team_id, enterprise_id, name, team_domain)
 		VALUES
-			(:user_id, :team_id, :name, :team_domain)
+			(:user_id, :team_id, :enterprise_id, :name, :team_domain)
+		ON CONFLICT (team_id, user_id) DO NOTHING
 	`
 	return db.NamedExec(sql, user)
 }
 
+func UpdateUserName(db *sqlx.DB, teamID, userID, name string) (sql.Result, error) {
+	sql := `
+		UPDATE gms.users
+		SET name = $3, modified_at = now()
+		WHERE team_id = $1 AND user_id = $2
+	`
+	return db.Exec(sql, teamID, userID, name)
+}
+
+// SetTextBoards picks the text boards instead of the images for the user
+func SetTextBoards(db *sqlx.DB, teamID, userID string, textBoards bool) error {
+	sql := `
+		UPDATE gms.users
+		SET text_boards = $3, modified_at = now()
+		WHERE team_id = $1 AND user_id = $2
+	`
+	_, err := db.Exec(sql, teamID, userID, textBoards)
+	return err
+}
+
 func GetAll(db *sqlx.DB) ([]User, error) {
 	users := []User{}
 	sql := `SELECT * FROM gms.users`
@@ -49,35 +94,53 @@ func GetAll(db *sqlx.DB) ([]User, error) {
 	return users, err
 }
 
-func GetOrSaveNew(db *sqlx.DB, userID, teamID, name, domain string) (User, error) {
-	user, err := GetUser(db, userID)
-	if err != nil {
-		// No rows try to create a new user
-		if err == sql.ErrNoRows {
-			user := User{
-				userID,
-				teamID,
-				name,
-				domain,
-				time.Now(),
-				time.Now(),
+// GetOrSaveNew returns the command user, the name is refreshed when it has
+// changed. The first user of the team creates also the team bot user
+func GetOrSaveNew(db *sqlx.DB, userID, teamID, enterpriseID, name, domain string) (User, error) {
+	user, err := GetUser(db, teamID, userID)
+	if err == nil {
+		if user.Name != name {
+			log.Println("Update the user name", user.Name, name)
+			if _, err = UpdateUserName(db, teamID, userID, name); err != nil {
+				return user, err
 			}
+			user.Name = name
+			user.Modified = time.Now()
+		}
+		return user, nil
+	}
 
-			log.Println("Create a new user", user)
-			result, err := NewUser(db, user)
-			if err != nil {
-				log.Fatalln("Could not create a new user", err)
-				return User{}, err
-			}
+	if err != sql.ErrNoRows {
+		log.Fatalln("Could not get the user from DB", userID)
+		return User{}, err
+	}
 
-			if rows, _ := result.RowsAffected(); rows != 1 {
-				log.Fatalln("Failed to create a new user")
-				return User{}, err
-			}
-		} else {
-			log.Fatalln("Could not get the user from DB", userID)
-			return User{}, err
-		}
+	// No rows try to create a new user
+	user = User{
+		UserID:       userID,
+		TeamID:       teamID,
+		EnterpriseID: enterpriseID,
+		Name:         name,
+		TeamDomain:   domain,
+		Created:      time.Now(),
+		Modified:     time.Now(),
+	}
+
+	log.Println("Create a new user", user)
+	result, err := NewUser(db, user)
+	if err != nil {
+		log.Fatalln("Could not create a new user", err)
+		return User{}, err
+	}
+
+	if rows, _ := result.RowsAffected(); rows != 1 {
+		log.Fatalln("Failed to create a new user")
+		return User{}, err
+	}
+
+	bot := User{UserID: BotUserID, TeamID: teamID, EnterpriseID: enterpriseID, Name: BotName, TeamDomain: domain}
+	if _, err = NewUser(db, bot); err != nil {
+		log.Println("Could not create the team bot user", err)
 	}
 	return user, nil
 }
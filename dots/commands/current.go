@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	dabdatastore "github.com/slack-games/slack-server/dots/datastore"
+)
+
+// CurrentCommand show the current user game state
+func CurrentCommand(db *sqlx.DB, teamID, userID string) slack.ResponseMessage {
+	log.Println("Show user current game", userID)
+	state, err := dabdatastore.GetUserLastState(db, teamID, userID)
+
+	// No state found
+	if err != nil {
+		return slack.TextOnly("Could not get the current game, but you could `/dots start @user` a new one")
+	}
+
+	message := fmt.Sprintf("Playing with %s in <#%s>, it's now <@%s> turn - _at %s_",
+		formatPlayers(state.GetPlayers()), state.ChannelID, state.TurnID, state.Created.Format("15:04:05 02-01-06"))
+	if isGameOver(state) {
+		message = fmt.Sprintf("Last game with %s is over. For a new game `/dots start @user`",
+			formatPlayers(state.GetPlayers()))
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, "Last game state", state.StateID)},
+	}
+}
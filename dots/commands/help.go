@@ -0,0 +1,48 @@
+package commands
+
+import "github.com/slack-games/slack-client"
+
+const helpText = `
+Start a game with 1 to 3 teammates of the channel _/dots start @user_ or with own grid size _/dots start 5x5 @user @other_.
+Players take turns drawing lines between neighbour dots, the dots are named by column letter and row number.
+Draw a line by typing _/dots line a1-a2_, completing a box gives you another turn.
+The player with most boxes wins.
+
+Good luck!
+`
+
+// HelpCommand show the possible info about available commands for user
+func HelpCommand() slack.ResponseMessage {
+
+	attachments := []slack.Attachment{
+		slack.Attachment{
+			Title: "/dots start @user [@user @user] - starts a new 4x4 game in channel",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/dots start [2-8]x[2-8] @user - starts a new game with grid size",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/dots line a1-a2 - draw line between dots",
+			Color: "#004FDD",
+		},
+		slack.Attachment{
+			Title: "/dots current - show the state of current game",
+			Color: "#FF4F20",
+		},
+		slack.Attachment{
+			Title: "/dots boards [text|image] - show the boards as text or images",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/dots help - Shows help message",
+			Color: "#76A0A0",
+		},
+	}
+
+	return slack.ResponseMessage{
+		Text:        helpText,
+		Attachments: attachments,
+	}
+}
@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/dots"
+	dabdatastore "github.com/slack-games/slack-server/dots/datastore"
+)
+
+// LineCommand draws the line between two dots for the user
+func LineCommand(db *sqlx.DB, teamID, userID, channelID string, line dots.Line) slack.ResponseMessage {
+	state, err := dabdatastore.GetUserLastState(db, teamID, userID)
+
+	if err != nil {
+		// No state found
+		if err == sql.ErrNoRows {
+			return slack.TextOnly("You can not draw any lines before the game has started `/dots start @user`")
+		}
+		log.Println("Error could not get the user state", err)
+		return slack.TextOnly("Could not get the last game state")
+	}
+
+	if isGameOver(state) {
+		log.Println("Game is already over")
+		return slack.TextOnly("Current game is over, but you can always start a new game `/dots start @user`")
+	}
+
+	if state.ChannelID != channelID {
+		return slack.TextOnly(fmt.Sprintf("The game is played in <#%s>, draw your lines there", state.ChannelID))
+	}
+
+	if state.TurnID != userID {
+		return slack.TextOnly(fmt.Sprintf("It's not your turn, waiting for <@%s>", state.TurnID))
+	}
+
+	game, err := dabdatastore.CreateDotsGame(state)
+	if err != nil {
+		log.Println("Could not create the game from state", err)
+		return slack.TextOnly("Could not read the game state")
+	}
+
+	completed, err := game.DrawLine(line)
+	if err != nil {
+		return slack.TextOnly(fmt.Sprintf("Could not draw the line: %s :scream_cat:", err))
+	}
+
+	newState := dabdatastore.CreateStateFromGame(game, state)
+	stateID, err := dabdatastore.NewState(db, *newState)
+	if err == datastore.ErrConflict {
+		return slack.TextOnly("The game changed meanwhile, check it with `/dots current` and try again")
+	}
+	if err != nil {
+		log.Println("Could not save the new state", err)
+		return slack.TextOnly("Could not save the line")
+	}
+
+	message := fmt.Sprintf("<@%s> drew line *[%s]*, now it's <@%s> turn", userID, line, newState.TurnID)
+	if completed > 0 {
+		message = fmt.Sprintf("<@%s> drew line *[%s]* and completed %d box, draw another line",
+			userID, line, completed)
+	}
+
+	switch game.State {
+	case dots.WinState:
+		message = fmt.Sprintf(":tada: All boxes are done, <@%s> wins with %d boxes :tada:",
+			newState.TurnID, game.Scores()[game.Winner()-1])
+	case dots.DrawState:
+		message = "All boxes are done, the game is a draw"
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, "The current game state", stateID)},
+	}
+}
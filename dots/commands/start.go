@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/dots"
+	dabdatastore "github.com/slack-games/slack-server/dots/datastore"
+)
+
+// StartCommand starts a new game in channel, the players take turns in
+// the given order. Every player has to be a member of the channel, the check
+// is left out only when the team has no client to ask the members with
+func StartCommand(db *sqlx.DB, client *slack.Client, teamID, channelID string, players []string,
+	width, height int) slack.ResponseMessage {
+	seen := map[string]bool{}
+	for _, id := range players {
+		if seen[id] {
+			return slack.TextOnly("Every player could join the game only once")
+		}
+		seen[id] = true
+	}
+
+	game, err := dots.NewDots(width, height, len(players))
+	if err != nil {
+		return slack.TextOnly(err.Error())
+	}
+
+	if client != nil {
+		members, err := client.GetConversationMembers(channelID)
+		if err != nil {
+			log.Println("Could not get the channel members", channelID, err)
+			return slack.TextOnly("Could not check the channel members, invite the app to the channel and try again")
+		}
+
+		if id := missingMember(members, players); id != "" {
+			return slack.TextOnly(fmt.Sprintf("<@%s> is not in the channel, invite them before starting the game", id))
+		}
+	}
+
+	for _, id := range players {
+		state, err := dabdatastore.GetUserLastState(db, teamID, id)
+		if err != nil && err != sql.ErrNoRows {
+			log.Println("Error could not get the user state", err)
+			return slack.TextOnly("Could not get the last game state")
+		}
+
+		if err == nil && !isGameOver(state) {
+			return slack.TextOnly(fmt.Sprintf("<@%s> is already playing, finish the game before starting a new", id))
+		}
+	}
+
+	state := dabdatastore.GetNewState(game, teamID, players, channelID)
+
+	log.Println("Create a new dots state")
+	stateID, err := dabdatastore.NewState(db, state)
+	if err != nil {
+		log.Fatalln("Could not create a new state", err)
+	}
+
+	return slack.ResponseMessage{
+		Text: fmt.Sprintf("Created a new %dx%d game for %s, <@%s> draws the first line `/dots line a1-a2`",
+			width, height, formatPlayers(players), players[0]),
+		Attachments: []slack.Attachment{imageAttachment(db, "New game state", stateID)},
+	}
+}
+
+// missingMember returns the first player not in the channel members, empty
+// when everyone is there
+func missingMember(members, players []string) string {
+	inChannel := map[string]bool{}
+	for _, id := range members {
+		inChannel[id] = true
+	}
+
+	for _, id := range players {
+		if !inChannel[id] {
+			return id
+		}
+	}
+	return ""
+}
+
+func formatPlayers(players []string) string {
+	mentions := make([]string, len(players))
+	for i, id := range players {
+		mentions[i] = fmt.Sprintf("<@%s>", id)
+	}
+	return strings.Join(mentions, ", ")
+}
+
+func isGameOver(state dabdatastore.State) bool {
+	return state.Mode == fmt.Sprintf("%s", dots.GameOverState) ||
+		state.Mode == fmt.Sprintf("%s", dots.WinState) ||
+		state.Mode == fmt.Sprintf("%s", dots.DrawState) ||
+		state.Mode == datastore.ForfeitMode
+}
+
+func imageAttachment(db *sqlx.DB, title, stateID string) slack.Attachment {
+	return slack.Attachment{
+		Title:    title,
+		Fallback: boardText(db, stateID),
+		ImageURL: fmt.Sprintf("%s/game/dots/image/%s", os.Getenv("BASE_PATH"), stateID),
+		Color:    "#764FA5",
+	}
+}
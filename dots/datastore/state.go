@@ -0,0 +1,199 @@
+package datastore
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	gmsdatastore "github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/dots"
+)
+
+type State struct {
+	StateID      string         `db:"state_id"`
+	TeamID       string         `db:"team_id"`
+	Width        int            `db:"width"`
+	Height       int            `db:"height"`
+	Edges        string         `db:"edges"`
+	Boxes        string         `db:"boxes"`
+	TurnID       string         `db:"turn"`
+	Mode         string         `db:"mode"`
+	FirstUserID  string         `db:"first_user_id"`
+	SecondUserID string         `db:"second_user_id"`
+	ThirdUserID  sql.NullString `db:"third_user_id"`
+	FourthUserID sql.NullString `db:"fourth_user_id"`
+	ChannelID    string         `db:"channel_id"`
+	ParentID     string         `db:"parent_state_id"`
+	Created      time.Time      `db:"created_at"`
+}
+
+func (s State) String() string {
+	return fmt.Sprintf("#[%s] - %dx%d %s %s %v %s %s",
+		s.StateID, s.Width, s.Height, s.TurnID, s.Mode, s.GetPlayers(), s.ChannelID, s.Created)
+}
+
+// GetPlayers returns the players user IDs in turn order
+func (s State) GetPlayers() []string {
+	players := []string{s.FirstUserID, s.SecondUserID}
+
+	if s.ThirdUserID.Valid {
+		players = append(players, s.ThirdUserID.String)
+	}
+
+	if s.FourthUserID.Valid {
+		players = append(players, s.FourthUserID.String)
+	}
+	return players
+}
+
+// GetPlayer returns the player number from 1 for the user, 0 when the user
+// is not playing
+func (s State) GetPlayer(userID string) uint8 {
+	for i, id := range s.GetPlayers() {
+		if id == userID {
+			return uint8(i + 1)
+		}
+	}
+	return 0
+}
+
+// GetUserID returns the user playing as player number
+func (s State) GetUserID(player uint8) string {
+	players := s.GetPlayers()
+	if player < 1 || int(player) > len(players) {
+		return ""
+	}
+	return players[player-1]
+}
+
+// GetNewState creates the empty grid for the players in channel
+func GetNewState(game *dots.Dots, teamID string, players []string, channelID string) State {
+	state := State{
+		TeamID:       teamID,
+		Width:        game.Width,
+		Height:       game.Height,
+		Edges:        game.GetEdgesAsString(),
+		Boxes:        game.GetBoxesAsString(),
+		TurnID:       players[0],
+		Mode:         fmt.Sprintf("%s", game.State),
+		FirstUserID:  players[0],
+		SecondUserID: players[1],
+		ChannelID:    channelID,
+		ParentID:     "00000000-0000-0000-0000-000000000000",
+		Created:      time.Now(),
+	}
+
+	if len(players) > 2 {
+		state.ThirdUserID = sql.NullString{String: players[2], Valid: true}
+	}
+
+	if len(players) > 3 {
+		state.FourthUserID = sql.NullString{String: players[3], Valid: true}
+	}
+	return state
+}
+
+func CreateStateFromGame(game *dots.Dots, state State) *State {
+	newState := state
+
+	newState.StateID = ""
+	newState.Edges = game.GetEdgesAsString()
+	newState.Boxes = game.GetBoxesAsString()
+	newState.TurnID = state.GetUserID(game.Turn)
+	newState.Mode = fmt.Sprintf("%s", game.State)
+	newState.ParentID = state.StateID
+	newState.Created = time.Now()
+
+	return &newState
+}
+
+// CreateForfeitState ends the game on timeout, there's no single winner
+// among more players so the player who did not move is kept in turn
+func CreateForfeitState(state State) *State {
+	newState := state
+
+	newState.StateID = ""
+	newState.Mode = gmsdatastore.ForfeitMode
+	newState.ParentID = state.StateID
+	newState.Created = time.Now()
+
+	return &newState
+}
+
+func CreateDotsGame(state State) (*dots.Dots, error) {
+	return dots.CreateFromString(state.Width, state.Height, len(state.GetPlayers()),
+		state.Edges, state.Boxes, state.GetPlayer(state.TurnID), dots.GetState(state.Mode))
+}
+
+func GetState(db *sqlx.DB, id string) (State, error) {
+	state := State{}
+
+	err := db.Get(&state, `SELECT * FROM dab.states WHERE state_id=$1 LIMIT 1`, id)
+	return state, err
+}
+
+func GetUserLastState(db *sqlx.DB, teamID, userID string) (State, error) {
+	state := State{}
+
+	query := `
+		SELECT *
+		FROM dab.states
+		WHERE
+			team_id=$1 AND $2 IN (first_user_id, second_user_id, third_user_id, fourth_user_id)
+		ORDER BY created_at DESC LIMIT 1;
+	`
+
+	err := db.Get(&state, query, teamID, userID)
+	return state, err
+}
+
+// GetStaleStates returns the running games where the player in turn has not
+// moved since before, the game is running while it's the latest one of the
+// player in turn
+func GetStaleStates(db *sqlx.DB, before time.Time) ([]State, error) {
+	states := []State{}
+
+	query := `
+		SELECT s.*
+		FROM dab.states s
+		WHERE
+			s.mode = 'Turn' AND s.created_at < $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM dab.states n
+				WHERE
+					n.team_id = s.team_id AND n.created_at > s.created_at
+					AND s.turn IN (n.first_user_id, n.second_user_id, n.third_user_id, n.fourth_user_id)
+			)
+	`
+
+	err := db.Select(&states, query, before)
+	return states, err
+}
+
+// NewState saves the next game state, ErrConflict is returned when the state
+// has been continued already
+func NewState(db *sqlx.DB, state State) (string, error) {
+	sql := `
+		INSERT INTO dab.states
+			(team_id, width, height, edges, boxes, turn, mode, first_user_id, second_user_id,
+			third_user_id, fourth_user_id, channel_id, parent_state_id)
+		VALUES
+			(:team_id, :width, :height, :edges, :boxes, :turn, :mode, :first_user_id, :second_user_id,
+			:third_user_id, :fourth_user_id, :channel_id, :parent_state_id)
+		RETURNING state_id
+	`
+	var id string
+
+	rows, err := db.NamedQuery(sql, state)
+	if err != nil {
+		return id, gmsdatastore.CheckConflict(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		rows.Scan(&id)
+	}
+	return id, gmsdatastore.CheckConflict(rows.Err())
+}
@@ -0,0 +1,361 @@
+package dots
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultSize = 4
+	MinSize     = 2
+	MaxSize     = 8
+	MinPlayers  = 2
+	MaxPlayers  = 4
+)
+
+const (
+	GameOverState State = 1 << iota
+	DrawState
+	WinState
+	TurnState
+)
+
+var dotRegexp = regexp.MustCompile("^([a-z])(\\d{1,2})$")
+
+type State int
+
+func (s State) String() string {
+	var state string
+
+	switch s {
+	case GameOverState:
+		state = "GameOver"
+	case WinState:
+		state = "Win"
+	case DrawState:
+		state = "Draw"
+	case TurnState:
+		state = "Turn"
+	default:
+		state = "GameOver"
+	}
+
+	return state
+}
+
+func GetState(s string) State {
+	var state State
+
+	switch s {
+	case "GameOver":
+		state = GameOverState
+	case "Win":
+		state = WinState
+	case "Draw":
+		state = DrawState
+	case "Turn":
+		state = TurnState
+	default:
+		state = GameOverState
+	}
+	return state
+}
+
+// Dot is the grid point, X is the column (a, b, ..) and Y the row (1, 2, ..)
+type Dot struct {
+	X, Y int
+}
+
+func (d Dot) String() string {
+	return fmt.Sprintf("%c%d", 'a'+d.X, d.Y+1)
+}
+
+// ParseDot converts the notation like "a1" into the dot
+func ParseDot(value string) (Dot, error) {
+	matches := dotRegexp.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if matches == nil {
+		return Dot{}, fmt.Errorf("Invalid dot '%s'", value)
+	}
+
+	row, _ := strconv.Atoi(matches[2])
+	return Dot{int(matches[1][0] - 'a'), row - 1}, nil
+}
+
+// Line is the edge between two neighbour dots, From is always the top or
+// left dot
+type Line struct {
+	From, To Dot
+}
+
+func (l Line) String() string {
+	return fmt.Sprintf("%s-%s", l.From, l.To)
+}
+
+// IsHorizontal checks if the line goes from left to right
+func (l Line) IsHorizontal() bool {
+	return l.From.Y == l.To.Y
+}
+
+// ParseLine converts the notation like "a1-a2" into the line
+func ParseLine(value string) (Line, error) {
+	parts := strings.Split(value, "-")
+	if len(parts) != 2 {
+		return Line{}, fmt.Errorf("Invalid line '%s', expected like a1-a2", value)
+	}
+
+	from, err := ParseDot(parts[0])
+	if err != nil {
+		return Line{}, err
+	}
+
+	to, err := ParseDot(parts[1])
+	if err != nil {
+		return Line{}, err
+	}
+
+	// Normalize the direction
+	if to.X < from.X || to.Y < from.Y {
+		from, to = to, from
+	}
+
+	dx, dy := to.X-from.X, to.Y-from.Y
+	if !(dx == 1 && dy == 0) && !(dx == 0 && dy == 1) {
+		return Line{}, fmt.Errorf("Dots %s and %s are not neighbours", from, to)
+	}
+	return Line{from, to}, nil
+}
+
+// Dots is the game on grid of Width x Height boxes, the edges and boxes
+// hold the player number (1-4) who drew or completed them, 0 when free
+type Dots struct {
+	Width      int
+	Height     int
+	Horizontal [][]uint8
+	Vertical   [][]uint8
+	Boxes      [][]uint8
+	Players    int
+	// Turn is the player number from 1
+	Turn uint8
+	State
+}
+
+// NewDots creates the empty grid of boxes for the players
+func NewDots(width, height, players int) (*Dots, error) {
+	if width < MinSize || width > MaxSize || height < MinSize || height > MaxSize {
+		return nil, fmt.Errorf("Grid size should be between %d and %d", MinSize, MaxSize)
+	}
+
+	if players < MinPlayers || players > MaxPlayers {
+		return nil, fmt.Errorf("Game needs %d to %d players", MinPlayers, MaxPlayers)
+	}
+
+	return &Dots{
+		Width:      width,
+		Height:     height,
+		Horizontal: makeGrid(width, height+1),
+		Vertical:   makeGrid(width+1, height),
+		Boxes:      makeGrid(width, height),
+		Players:    players,
+		Turn:       1,
+		State:      TurnState,
+	}, nil
+}
+
+// makeGrid creates grid indexed by [x][y]
+func makeGrid(width, height int) [][]uint8 {
+	grid := make([][]uint8, width)
+	for x := range grid {
+		grid[x] = make([]uint8, height)
+	}
+	return grid
+}
+
+func (d *Dots) IsOver() bool {
+	return d.State == GameOverState || d.State == WinState || d.State == DrawState
+}
+
+// IsDrawn returns the player who drew the line, 0 when not drawn
+func (d *Dots) IsDrawn(line Line) uint8 {
+	if line.IsHorizontal() {
+		return d.Horizontal[line.From.X][line.From.Y]
+	}
+	return d.Vertical[line.From.X][line.From.Y]
+}
+
+func (d *Dots) inGrid(dot Dot) bool {
+	return dot.X >= 0 && dot.X <= d.Width && dot.Y >= 0 && dot.Y <= d.Height
+}
+
+func (d *Dots) isBoxClosed(x, y int) bool {
+	return d.Horizontal[x][y] != 0 && d.Horizontal[x][y+1] != 0 &&
+		d.Vertical[x][y] != 0 && d.Vertical[x+1][y] != 0
+}
+
+// DrawLine draws the line for the current player, the player who completes
+// a box keeps the turn. Returns the number of completed boxes
+func (d *Dots) DrawLine(line Line) (int, error) {
+	if d.IsOver() {
+		return 0, errors.New("Game over could not draw line")
+	}
+
+	if !d.inGrid(line.From) || !d.inGrid(line.To) {
+		return 0, fmt.Errorf("Line %s is out of grid", line)
+	}
+
+	if d.IsDrawn(line) != 0 {
+		return 0, fmt.Errorf("Line %s is already drawn", line)
+	}
+
+	// Boxes on both sides of the line
+	var candidates []Dot
+	x, y := line.From.X, line.From.Y
+
+	if line.IsHorizontal() {
+		d.Horizontal[x][y] = d.Turn
+		candidates = []Dot{{x, y - 1}, {x, y}}
+	} else {
+		d.Vertical[x][y] = d.Turn
+		candidates = []Dot{{x - 1, y}, {x, y}}
+	}
+
+	completed := 0
+	for _, box := range candidates {
+		if box.X < 0 || box.X >= d.Width || box.Y < 0 || box.Y >= d.Height {
+			continue
+		}
+
+		if d.Boxes[box.X][box.Y] == 0 && d.isBoxClosed(box.X, box.Y) {
+			d.Boxes[box.X][box.Y] = d.Turn
+			completed++
+		}
+	}
+
+	if d.isFull() {
+		d.finish()
+	} else if completed == 0 {
+		d.Turn = d.Turn%uint8(d.Players) + 1
+	}
+
+	return completed, nil
+}
+
+func (d *Dots) isFull() bool {
+	for x := range d.Boxes {
+		for y := range d.Boxes[x] {
+			if d.Boxes[x][y] == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// finish sets the winner into turn, shared best score is a draw
+func (d *Dots) finish() {
+	scores := d.Scores()
+	best, winners := 0, 0
+
+	for player, score := range scores {
+		if score > best {
+			best, winners = score, 1
+			d.Turn = uint8(player + 1)
+		} else if score == best {
+			winners++
+		}
+	}
+
+	if winners > 1 {
+		d.State = DrawState
+	} else {
+		d.State = WinState
+	}
+}
+
+// Scores returns the completed boxes per player, index 0 is player 1
+func (d *Dots) Scores() []int {
+	scores := make([]int, d.Players)
+	for x := range d.Boxes {
+		for y := range d.Boxes[x] {
+			if owner := d.Boxes[x][y]; owner != 0 {
+				scores[owner-1]++
+			}
+		}
+	}
+	return scores
+}
+
+// Winner returns the winner player number, 0 when not won
+func (d *Dots) Winner() uint8 {
+	if d.State != WinState {
+		return 0
+	}
+	return d.Turn
+}
+
+// GetEdgesAsString serializes the horizontal and then vertical lines
+func (d *Dots) GetEdgesAsString() string {
+	return gridToString(d.Horizontal) + gridToString(d.Vertical)
+}
+
+// GetBoxesAsString serializes the box owners
+func (d *Dots) GetBoxesAsString() string {
+	return gridToString(d.Boxes)
+}
+
+// Rows are written one after another
+func gridToString(grid [][]uint8) string {
+	var cells []byte
+
+	for y := 0; len(grid) > 0 && y < len(grid[0]); y++ {
+		for x := range grid {
+			cells = append(cells, '0'+grid[x][y])
+		}
+	}
+	return string(cells)
+}
+
+func stringToGrid(value string, grid [][]uint8) error {
+	if len(grid) == 0 || len(value) != len(grid)*len(grid[0]) {
+		return fmt.Errorf("Invalid grid length %d", len(value))
+	}
+
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '0'+MaxPlayers {
+			return fmt.Errorf("Invalid grid value '%c'", value[i])
+		}
+		grid[i%len(grid)][i/len(grid)] = value[i] - '0'
+	}
+	return nil
+}
+
+// CreateFromString restores the game from the edges and boxes strings
+func CreateFromString(width, height, players int, edges, boxes string, turn uint8, state State) (*Dots, error) {
+	game, err := NewDots(width, height, players)
+	if err != nil {
+		return nil, err
+	}
+
+	split := width * (height + 1)
+	if len(edges) < split {
+		return nil, fmt.Errorf("Invalid edges length %d", len(edges))
+	}
+
+	if err = stringToGrid(edges[:split], game.Horizontal); err != nil {
+		return nil, err
+	}
+
+	if err = stringToGrid(edges[split:], game.Vertical); err != nil {
+		return nil, err
+	}
+
+	if err = stringToGrid(boxes, game.Boxes); err != nil {
+		return nil, err
+	}
+
+	game.Turn = turn
+	game.State = state
+	return game, nil
+}
@@ -0,0 +1,68 @@
+package dots
+
+import "testing"
+
+func TestParseLine(t *testing.T) {
+	line, err := ParseLine("b2-a2")
+	if err != nil || line.From != (Dot{0, 1}) || line.To != (Dot{1, 1}) || !line.IsHorizontal() {
+		t.Error("Line should be normalized from left to right", line, err)
+	}
+
+	if _, err := ParseLine("a1-b2"); err == nil {
+		t.Error("Diagonal line should not be allowed")
+	}
+}
+
+func TestBoxKeepsTurn(t *testing.T) {
+	game, _ := NewDots(2, 2, 2)
+
+	for _, value := range []string{"a1-b1", "a2-b2", "a1-a2"} {
+		line, _ := ParseLine(value)
+		game.DrawLine(line)
+	}
+
+	if game.Turn != 2 {
+		t.Error("Second player should be in turn", game.Turn)
+	}
+
+	line, _ := ParseLine("b1-b2")
+	if completed, err := game.DrawLine(line); err != nil || completed != 1 {
+		t.Error("Line should complete the box", completed, err)
+	}
+
+	if game.Turn != 2 || game.Boxes[0][0] != 2 {
+		t.Error("Second player should keep the turn and own the box", game.Turn)
+	}
+
+	if _, err := game.DrawLine(line); err == nil {
+		t.Error("Line could be drawn only once")
+	}
+}
+
+func TestGameOverAndRestore(t *testing.T) {
+	game, _ := NewDots(2, 2, 3)
+
+	lines := []string{
+		"a1-b1", "b1-c1", "a1-a2", "c1-c2", "a2-b2", "b2-c2",
+		"a2-a3", "a3-b3", "c2-c3", "b3-c3", "b1-b2", "b2-b3",
+	}
+	for _, value := range lines {
+		line, _ := ParseLine(value)
+		if _, err := game.DrawLine(line); err != nil {
+			t.Fatal("Could not draw line", value, err)
+		}
+	}
+
+	if !game.IsOver() {
+		t.Fatal("Game should be over when all boxes are done", game.State)
+	}
+
+	restored, err := CreateFromString(2, 2, 3, game.GetEdgesAsString(), game.GetBoxesAsString(), game.Turn, game.State)
+	if err != nil {
+		t.Fatal("Could not restore the game", err)
+	}
+
+	if restored.GetEdgesAsString() != game.GetEdgesAsString() || restored.Winner() != game.Winner() {
+		t.Error("Restored game should match", restored.GetEdgesAsString(), game.GetEdgesAsString())
+	}
+}
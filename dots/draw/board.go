@@ -0,0 +1,191 @@
+package draw
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"math"
+	"os"
+
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dimg"
+	kit "github.com/llgcode/draw2d/draw2dkit"
+	"github.com/slack-games/slack-server/dots"
+)
+
+const (
+	Width  = 350
+	Height = 400
+	Offset = 30.0
+	// Space below the grid for the scores
+	Footer = 60.0
+)
+
+var DefaultColor, FirstColor, SecondColor, ThirdColor, FourthColor color.RGBA
+
+// PlayerColors in the player order, index 0 is player 1
+var PlayerColors []color.RGBA
+
+func init() {
+	// #444444
+	DefaultColor = color.RGBA{0x44, 0x44, 0x44, 0xff}
+	// #0A63BB
+	FirstColor = color.RGBA{0x0a, 0x63, 0xbb, 0xff}
+	// #6D083E
+	SecondColor = color.RGBA{0x6d, 0x08, 0x3e, 0xff}
+	// #2E7D32
+	ThirdColor = color.RGBA{0x2e, 0x7d, 0x32, 0xff}
+	// #E65100
+	FourthColor = color.RGBA{0xe6, 0x51, 0x00, 0xff}
+
+	PlayerColors = []color.RGBA{FirstColor, SecondColor, ThirdColor, FourthColor}
+}
+
+// cellSize fits the grid into the image
+func cellSize(game *dots.Dots) float64 {
+	return math.Min((Width-2*Offset)/float64(game.Width), (Height-2*Offset-Footer)/float64(game.Height))
+}
+
+func playerColor(player uint8, alpha uint8) color.RGBA {
+	c := PlayerColors[player-1]
+	c.A = alpha
+	return c
+}
+
+// DrawBoxes fills the completed boxes with the player color
+func DrawBoxes(gc *draw2dimg.GraphicContext, game *dots.Dots) {
+	size := cellSize(game)
+
+	for x := range game.Boxes {
+		for y := range game.Boxes[x] {
+			owner := game.Boxes[x][y]
+			if owner == 0 {
+				continue
+			}
+
+			xPos := Offset + float64(x)*size
+			yPos := Offset + float64(y)*size
+
+			gc.Save()
+			gc.SetFillColor(playerColor(owner, 0x55))
+			kit.Rectangle(gc, xPos, yPos, xPos+size, yPos+size)
+			gc.Fill()
+			gc.Restore()
+		}
+	}
+}
+
+// DrawLines draws the lines with the color of the player who drew them
+func DrawLines(gc *draw2dimg.GraphicContext, game *dots.Dots) {
+	size := cellSize(game)
+
+	line := func(player uint8, x1, y1, x2, y2 float64) {
+		if player == 0 {
+			return
+		}
+
+		gc.Save()
+		gc.SetStrokeColor(playerColor(player, 0xff))
+		gc.SetLineWidth(4)
+		gc.SetLineCap(draw2d.RoundCap)
+		gc.MoveTo(x1, y1)
+		gc.LineTo(x2, y2)
+		gc.Stroke()
+		gc.Restore()
+	}
+
+	for x := range game.Horizontal {
+		for y := range game.Horizontal[x] {
+			xPos, yPos := Offset+float64(x)*size, Offset+float64(y)*size
+			line(game.Horizontal[x][y], xPos, yPos, xPos+size, yPos)
+		}
+	}
+
+	for x := range game.Vertical {
+		for y := range game.Vertical[x] {
+			xPos, yPos := Offset+float64(x)*size, Offset+float64(y)*size
+			line(game.Vertical[x][y], xPos, yPos, xPos, yPos+size)
+		}
+	}
+}
+
+// DrawDots draws the grid dots with the column and row names
+func DrawDots(gc *draw2dimg.GraphicContext, game *dots.Dots) {
+	size := cellSize(game)
+
+	gc.Save()
+	gc.SetFillColor(DefaultColor)
+	for x := 0; x <= game.Width; x++ {
+		for y := 0; y <= game.Height; y++ {
+			kit.Circle(gc, Offset+float64(x)*size, Offset+float64(y)*size, 4)
+			gc.Fill()
+		}
+	}
+
+	gc.SetFillColor(color.Black)
+	gc.SetFontSize(10)
+	for x := 0; x <= game.Width; x++ {
+		gc.FillStringAt(fmt.Sprintf("%c", 'a'+x), Offset+float64(x)*size-3, Offset-12)
+	}
+	for y := 0; y <= game.Height; y++ {
+		gc.FillStringAt(fmt.Sprintf("%d", y+1), Offset-22, Offset+float64(y)*size+4)
+	}
+	gc.Restore()
+}
+
+// DrawScores lists the players with the colors and scores
+func DrawScores(gc *draw2dimg.GraphicContext, game *dots.Dots, names []string) {
+	scores := game.Scores()
+
+	gc.Save()
+	gc.SetFontSize(12)
+	for i, score := range scores {
+		name := fmt.Sprintf("Player %d", i+1)
+		if i < len(names) {
+			name = names[i]
+		}
+
+		marker := ""
+		if !game.IsOver() && game.Turn == uint8(i+1) {
+			marker = " <"
+		}
+		if game.Winner() == uint8(i+1) {
+			marker = " wins"
+		}
+
+		xPos := Offset + float64(i%2)*150
+		yPos := Height - Footer + float64(i/2)*22
+
+		gc.SetFillColor(PlayerColors[i])
+		gc.FillStringAt(fmt.Sprintf("%s: %d%s", name, score, marker), xPos, yPos)
+	}
+	gc.Restore()
+}
+
+func Draw(game *dots.Dots, names []string) image.Image {
+
+	// Initialize the graphic context on an RGBA image
+	dest := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	gc := draw2dimg.NewGraphicContext(dest)
+
+	fontPath := os.Getenv("FONT_PATH")
+	if fontPath == "" {
+		log.Fatalln("No FONT_PATH has been set")
+	}
+
+	draw2d.SetFontFolder(fontPath)
+
+	gc.SetFontData(draw2d.FontData{
+		Name:   "Surface",
+		Family: draw2d.FontFamilySans,
+		Style:  draw2d.FontStyleBold,
+	})
+
+	DrawBoxes(gc, game)
+	DrawLines(gc, game)
+	DrawDots(gc, game)
+	DrawScores(gc, game, names)
+
+	return dest
+}
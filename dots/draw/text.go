@@ -0,0 +1,69 @@
+package draw
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/slack-games/slack-server/dots"
+)
+
+// Text returns the grid with the drawn lines and the box owners as text, for
+// the clients without images
+func Text(game *dots.Dots) string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("```\n   ")
+	for x := 0; x <= game.Width; x++ {
+		buffer.WriteString(fmt.Sprintf("%-4c", 'a'+x))
+	}
+	buffer.WriteString("\n")
+
+	for y := 0; y <= game.Height; y++ {
+		buffer.WriteString(fmt.Sprintf("%2d ", y+1))
+		for x := 0; x < game.Width; x++ {
+			buffer.WriteString("+")
+			if game.Horizontal[x][y] != 0 {
+				buffer.WriteString("---")
+			} else {
+				buffer.WriteString("   ")
+			}
+		}
+		buffer.WriteString("+\n")
+
+		if y == game.Height {
+			break
+		}
+
+		buffer.WriteString("   ")
+		for x := 0; x <= game.Width; x++ {
+			if game.Vertical[x][y] != 0 {
+				buffer.WriteString("|")
+			} else {
+				buffer.WriteString(" ")
+			}
+			if x < game.Width {
+				if owner := game.Boxes[x][y]; owner != 0 {
+					buffer.WriteString(fmt.Sprintf(" %d ", owner))
+				} else {
+					buffer.WriteString("   ")
+				}
+			}
+		}
+		buffer.WriteString("\n")
+	}
+	// The free edges at the end of the rows leave the trailing spaces
+	lines := strings.Split(buffer.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	buffer.Reset()
+	buffer.WriteString(strings.Join(lines, "\n"))
+	buffer.WriteString("```")
+
+	for i, score := range game.Scores() {
+		buffer.WriteString(fmt.Sprintf("\nPlayer %d: %d", i+1, score))
+	}
+	return buffer.String()
+}
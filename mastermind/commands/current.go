@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/mastermind"
+	mmsdatastore "github.com/slack-games/slack-server/mastermind/datastore"
+)
+
+// CurrentCommand show the current user game state
+func CurrentCommand(db *sqlx.DB, teamID, userID string) slack.ResponseMessage {
+	log.Println("Show user current game", userID)
+	state, err := mmsdatastore.GetUserLastState(db, teamID, userID)
+
+	// No state found
+	if err != nil {
+		return slack.TextOnly("Could not get the current game, but you could `/mastermind start` a new one")
+	}
+
+	game := mmsdatastore.CreateMastermindGame(state)
+
+	return slack.ResponseMessage{
+		Text: fmt.Sprintf("Mastermind current state, %d of %d guesses made with colors *%s*",
+			len(game.Guesses), mastermind.Steps, game.AllowedColors()),
+		Attachments: []slack.Attachment{imageAttachment(db, "Last game state", state.StateID)},
+	}
+}
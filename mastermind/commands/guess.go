@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/mastermind"
+	mmsdatastore "github.com/slack-games/slack-server/mastermind/datastore"
+)
+
+// GuessCommand checks the guess against the secret code
+func GuessCommand(db *sqlx.DB, teamID, userID, guess string) slack.ResponseMessage {
+	state, err := mmsdatastore.GetUserLastState(db, teamID, userID)
+
+	if err != nil {
+		// No state found
+		if err == sql.ErrNoRows {
+			return slack.TextOnly("You can not make any guesses before the game has started `/mastermind start`")
+		}
+		log.Println("Error could not get the user state", err)
+		return slack.TextOnly("Could not get the last game state")
+	}
+
+	if isGameOver(state) {
+		log.Println("Game is already over")
+		return slack.TextOnly("Current game is over, but you can always start a new game `/mastermind start`")
+	}
+
+	game := mmsdatastore.CreateMastermindGame(state)
+	feedback, err := game.MakeGuess(guess)
+	if err != nil {
+		return slack.TextOnly(fmt.Sprintf("Could not make the guess: %s", err))
+	}
+
+	newState := mmsdatastore.CreateStateFromGame(game, state)
+	stateID, err := mmsdatastore.NewState(db, *newState)
+	if err == datastore.ErrConflict {
+		return slack.TextOnly("The game changed meanwhile, check it with `/mastermind current` and try again")
+	}
+	if err != nil {
+		log.Println("Could not save the new state", err)
+		return slack.TextOnly("Could not save the guess")
+	}
+
+	message := fmt.Sprintf("Your guess *%s* got %d black and %d white %s",
+		game.Guesses[len(game.Guesses)-1], feedback.Black, feedback.White, feedback)
+
+	switch game.State {
+	case mastermind.WinState:
+		message = fmt.Sprintf(":tada: You cracked the code *%s* in %d guesses :tada:", game.Code, len(game.Guesses))
+	case mastermind.GameOverState:
+		message = fmt.Sprintf("No more guesses left, the code was *%s*. For a new game `/mastermind start`", game.Code)
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, "The current game state", stateID)},
+	}
+}
@@ -0,0 +1,52 @@
+package commands
+
+import "github.com/slack-games/slack-client"
+
+const helpText = `
+The bot picks a secret code of colored pegs and you have 10 guesses to crack it.
+Colors are R - red, G - green, B - blue, Y - yellow, O - orange, P - purple, W - white and K - black.
+Make a guess by typing _/mastermind guess RGBY_, for each guess you get black peg for the right
+color in right position and white peg for the right color in wrong position.
+
+Good luck!
+`
+
+// HelpCommand show the possible info about available commands for user
+func HelpCommand() slack.ResponseMessage {
+
+	attachments := []slack.Attachment{
+		slack.Attachment{
+			Title: "/mastermind start - starts a new game with code of 4 pegs and 6 colors",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/mastermind start [3-6] [4-8] - starts a new game with code length and color count",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/mastermind guess RGBY - make a guess",
+			Color: "#004FDD",
+		},
+		slack.Attachment{
+			Title: "/mastermind solve - show how many codes match the feedback so far",
+			Color: "#FF4F20",
+		},
+		slack.Attachment{
+			Title: "/mastermind current - show the state of current game",
+			Color: "#FF4F20",
+		},
+		slack.Attachment{
+			Title: "/mastermind boards [text|image] - show the boards as text or images",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/mastermind help - Shows help message",
+			Color: "#76A0A0",
+		},
+	}
+
+	return slack.ResponseMessage{
+		Text:        helpText,
+		Attachments: attachments,
+	}
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"errors"
+	"image"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	mmsdatastore "github.com/slack-games/slack-server/mastermind/datastore"
+	drawBoard "github.com/slack-games/slack-server/mastermind/draw"
+)
+
+// GetGameImage returns the image by state
+func GetGameImage(db *sqlx.DB, stateID string) (image.Image, error) {
+	state, err := mmsdatastore.GetState(db, stateID)
+	if err != nil {
+		return nil, errors.New("Could not get the state")
+	}
+
+	return drawBoard.Draw(mmsdatastore.CreateMastermindGame(state)), nil
+}
+
+// GetGameText returns the board by state as text
+func GetGameText(db *sqlx.DB, stateID string) (string, error) {
+	state, err := mmsdatastore.GetState(db, stateID)
+	if err != nil {
+		return "", errors.New("Could not get the state")
+	}
+
+	return drawBoard.Text(mmsdatastore.CreateMastermindGame(state)), nil
+}
+
+// boardText is the text board of the attachment, shown instead of the image
+// when it could not be loaded
+func boardText(db *sqlx.DB, stateID string) string {
+	text, err := GetGameText(db, stateID)
+	if err != nil {
+		log.Println("Could not get the text board", stateID, err)
+		return "The game board"
+	}
+	return text
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	mmsdatastore "github.com/slack-games/slack-server/mastermind/datastore"
+)
+
+// SolveCommand shows how many codes are still consistent with the feedback
+func SolveCommand(db *sqlx.DB, teamID, userID string) slack.ResponseMessage {
+	state, err := mmsdatastore.GetUserLastState(db, teamID, userID)
+
+	// No state found
+	if err != nil {
+		return slack.TextOnly("Could not get the current game, but you could `/mastermind start` a new one")
+	}
+
+	if isGameOver(state) {
+		return slack.TextOnly("Current game is over, but you can always start a new game `/mastermind start`")
+	}
+
+	game := mmsdatastore.CreateMastermindGame(state)
+	count := game.ConsistentCodes()
+
+	if count == 1 {
+		return slack.TextOnly(":mag: Only one code matches all the feedback, you should know it now")
+	}
+
+	return slack.TextOnly(fmt.Sprintf(":mag: There are %d codes matching all the feedback so far", count))
+}
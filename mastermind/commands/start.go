@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/mastermind"
+	mmsdatastore "github.com/slack-games/slack-server/mastermind/datastore"
+)
+
+// StartCommand starts a new game with the code length and color count
+func StartCommand(db *sqlx.DB, teamID, userID string, length, colors int) slack.ResponseMessage {
+	if err := mastermind.ValidateConfig(length, colors); err != nil {
+		return slack.TextOnly(err.Error())
+	}
+
+	state, err := mmsdatastore.GetUserLastState(db, teamID, userID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("Error could not get the user state", err)
+		return slack.TextOnly("Could not get the last game state")
+	}
+
+	if err == nil && !isGameOver(state) {
+		return slack.ResponseMessage{
+			Text:        "There's already existing a game, you have to finish it before starting a new",
+			Attachments: []slack.Attachment{imageAttachment(db, "Last game state", state.StateID)},
+		}
+	}
+
+	newState := mmsdatastore.GetNewState(teamID, userID, length, colors, datastore.NewSeed())
+
+	log.Println("Generate a new mastermind state")
+	stateID, err := mmsdatastore.NewState(db, newState)
+	if err != nil {
+		log.Fatalln("Could not create a new state", err)
+	}
+
+	game := mmsdatastore.CreateMastermindGame(newState)
+	return slack.ResponseMessage{
+		Text: fmt.Sprintf("The bot picked a secret code of %d pegs from colors *%s*, crack it in %d guesses `/mastermind guess %s`",
+			length, game.AllowedColors(), mastermind.Steps, game.ExampleGuess()),
+		Attachments: []slack.Attachment{imageAttachment(db, "New game state", stateID)},
+	}
+}
+
+func isGameOver(state mmsdatastore.State) bool {
+	return state.Mode == fmt.Sprintf("%s", mastermind.GameOverState) ||
+		state.Mode == fmt.Sprintf("%s", mastermind.WinState) ||
+		state.Mode == datastore.ForfeitMode
+}
+
+func imageAttachment(db *sqlx.DB, title, stateID string) slack.Attachment {
+	return slack.Attachment{
+		Title:    title,
+		Fallback: boardText(db, stateID),
+		ImageURL: fmt.Sprintf("%s/game/mastermind/image/%s", os.Getenv("BASE_PATH"), stateID),
+		Color:    "#764FA5",
+	}
+}
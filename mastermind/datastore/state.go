@@ -0,0 +1,163 @@
+package datastore
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	gmsdatastore "github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/mastermind"
+)
+
+type State struct {
+	StateID  string    `db:"state_id"`
+	TeamID   string    `db:"team_id"`
+	Code     string    `db:"code"`
+	Guesses  string    `db:"guesses"`
+	Length   int       `db:"code_length"`
+	Colors   int       `db:"colors"`
+	Mode     string    `db:"mode"`
+	UserID   string    `db:"user_id"`
+	Seed     int64     `db:"seed"`
+	ParentID string    `db:"parent_state_id"`
+	Created  time.Time `db:"created_at"`
+}
+
+func (s State) String() string {
+	return fmt.Sprintf("#[%s] - %s %s %s %s %s",
+		s.StateID, s.Code, s.Guesses, s.Mode, s.UserID, s.Created)
+}
+
+// GetNewState picks the secret code for the new game, the same seed gives
+// always the same code
+func GetNewState(teamID, userID string, length, colors int, seed int64) State {
+	return State{
+		TeamID:   teamID,
+		Code:     mastermind.RandomCode(gmsdatastore.Rand(seed, 0), length, colors),
+		Guesses:  "",
+		Length:   length,
+		Colors:   colors,
+		Mode:     fmt.Sprintf("%s", mastermind.TurnState),
+		UserID:   userID,
+		Seed:     seed,
+		ParentID: "00000000-0000-0000-0000-000000000000",
+		Created:  time.Now(),
+	}
+}
+
+// CreateStateFromGame converts the game back to the state, the guesses are
+// stored as comma separated list
+func CreateStateFromGame(game *mastermind.Mastermind, state State) *State {
+	return &State{
+		TeamID:   state.TeamID,
+		Code:     game.Code,
+		Guesses:  strings.Join(game.Guesses, ","),
+		Length:   game.Length,
+		Colors:   game.Colors,
+		Mode:     fmt.Sprintf("%s", game.State),
+		UserID:   state.UserID,
+		Seed:     state.Seed,
+		ParentID: state.StateID,
+		Created:  time.Now(),
+	}
+}
+
+// CreateForfeitState ends the game on timeout
+func CreateForfeitState(state State) *State {
+	return &State{
+		TeamID:   state.TeamID,
+		Code:     state.Code,
+		Guesses:  state.Guesses,
+		Length:   state.Length,
+		Colors:   state.Colors,
+		Mode:     gmsdatastore.ForfeitMode,
+		UserID:   state.UserID,
+		Seed:     state.Seed,
+		ParentID: state.StateID,
+		Created:  time.Now(),
+	}
+}
+
+func CreateMastermindGame(state State) *mastermind.Mastermind {
+	var guesses []string
+	if state.Guesses != "" {
+		guesses = strings.Split(state.Guesses, ",")
+	}
+
+	return &mastermind.Mastermind{
+		Code:    state.Code,
+		Guesses: guesses,
+		Length:  state.Length,
+		Colors:  state.Colors,
+		State:   mastermind.GetState(state.Mode),
+	}
+}
+
+func GetState(db *sqlx.DB, id string) (State, error) {
+	state := State{}
+
+	err := db.Get(&state, `SELECT * FROM mms.states WHERE state_id=$1 LIMIT 1`, id)
+	return state, err
+}
+
+func GetUserLastState(db *sqlx.DB, teamID, userID string) (State, error) {
+	state := State{}
+
+	query := `
+		SELECT *
+		FROM mms.states
+		WHERE
+			team_id=$1 AND user_id=$2
+		ORDER BY created_at DESC LIMIT 1;
+	`
+
+	err := db.Get(&state, query, teamID, userID)
+	return state, err
+}
+
+// GetStaleStates returns the running games without any guesses since before,
+// the game is running while it's the latest one of the user
+func GetStaleStates(db *sqlx.DB, before time.Time) ([]State, error) {
+	states := []State{}
+
+	query := `
+		SELECT s.*
+		FROM mms.states s
+		WHERE
+			s.mode = 'Turn' AND s.created_at < $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM mms.states n
+				WHERE
+					n.team_id = s.team_id AND n.user_id = s.user_id AND n.created_at > s.created_at
+			)
+	`
+
+	err := db.Select(&states, query, before)
+	return states, err
+}
+
+// NewState saves the next game state, ErrConflict is returned when the state
+// has been continued already
+func NewState(db *sqlx.DB, state State) (string, error) {
+	sql := `
+		INSERT INTO mms.states
+			(team_id, code, guesses, code_length, colors, mode, user_id, seed, parent_state_id)
+		VALUES
+			(:team_id, :code, :guesses, :code_length, :colors, :mode, :user_id, :seed, :parent_state_id)
+		RETURNING state_id
+	`
+	var id string
+
+	rows, err := db.NamedQuery(sql, state)
+	if err != nil {
+		return id, gmsdatastore.CheckConflict(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		rows.Scan(&id)
+	}
+	return id, gmsdatastore.CheckConflict(rows.Err())
+}
@@ -0,0 +1,143 @@
+package draw
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"os"
+
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dimg"
+	kit "github.com/llgcode/draw2d/draw2dkit"
+	"github.com/slack-games/slack-server/mastermind"
+)
+
+const (
+	Width     = 350
+	Offset    = 25.0
+	RowHeight = 36.0
+	PegSize   = 12.0
+)
+
+var DefaultColor, RedColor, GreenColor color.RGBA
+
+// PegColors maps the palette letters to the drawing colors
+var PegColors map[byte]color.RGBA
+
+func init() {
+	// #444444
+	DefaultColor = color.RGBA{0x44, 0x44, 0x44, 0xff}
+	// #FF0000
+	RedColor = color.RGBA{0xff, 0x0, 0x0, 0xff}
+	// #00AA00
+	GreenColor = color.RGBA{0x00, 0xaa, 0x0, 0xff}
+
+	PegColors = map[byte]color.RGBA{
+		'R': color.RGBA{0xe5, 0x39, 0x35, 0xff},
+		'G': color.RGBA{0x43, 0xa0, 0x47, 0xff},
+		'B': color.RGBA{0x1e, 0x88, 0xe5, 0xff},
+		'Y': color.RGBA{0xfd, 0xd8, 0x35, 0xff},
+		'O': color.RGBA{0xfb, 0x8c, 0x00, 0xff},
+		'P': color.RGBA{0x8e, 0x24, 0xaa, 0xff},
+		'W': color.RGBA{0xf5, 0xf5, 0xf5, 0xff},
+		'K': color.RGBA{0x21, 0x21, 0x21, 0xff},
+	}
+}
+
+// Height of the image grows with the number of guesses
+func Height(game *mastermind.Mastermind) int {
+	rows := len(game.Guesses) + 1
+	return int(Offset*3 + RowHeight*float64(rows))
+}
+
+// DrawRow draws the guess pegs with the feedback pegs on right side
+func DrawRow(gc *draw2dimg.GraphicContext, index int, guess string, feedback mastermind.Feedback) {
+	yPos := Offset*2 + RowHeight*float64(index) + RowHeight/2
+
+	gc.Save()
+	gc.SetFillColor(color.Black)
+	gc.SetFontSize(12)
+	gc.FillStringAt(fmt.Sprintf("%d.", index+1), Offset-10, yPos+5)
+
+	gc.SetStrokeColor(DefaultColor)
+	gc.SetLineWidth(1)
+	for i := 0; i < len(guess); i++ {
+		xPos := Offset + 30 + float64(i)*(PegSize*2+6)
+
+		gc.SetFillColor(PegColors[guess[i]])
+		kit.Circle(gc, xPos, yPos, PegSize)
+		gc.FillStroke()
+	}
+
+	// Feedback pegs, black first
+	for i := 0; i < feedback.Black+feedback.White; i++ {
+		xPos := Width - Offset - 100 + float64(i)*14
+
+		gc.SetFillColor(color.Black)
+		if i >= feedback.Black {
+			gc.SetFillColor(color.White)
+		}
+		kit.Circle(gc, xPos, yPos, 5)
+		gc.FillStroke()
+	}
+	gc.Restore()
+}
+
+// DrawState shows the game result and reveals the code when over
+func DrawState(gc *draw2dimg.GraphicContext, game *mastermind.Mastermind, height int) {
+	var message string
+
+	gc.Save()
+	switch game.State {
+	case mastermind.WinState:
+		gc.SetFillColor(GreenColor)
+		message = fmt.Sprintf("Cracked in %d", len(game.Guesses))
+	case mastermind.GameOverState:
+		gc.SetFillColor(RedColor)
+		message = fmt.Sprintf("GameOver, code %s", game.Code)
+	default:
+		gc.SetFillColor(color.Black)
+		message = fmt.Sprintf("Guesses left %d", mastermind.Steps-len(game.Guesses))
+	}
+
+	gc.SetFontSize(16)
+	gc.FillStringAt(message, Offset, float64(height)-Offset/2)
+	gc.Restore()
+}
+
+func Draw(game *mastermind.Mastermind) image.Image {
+	height := Height(game)
+
+	// Initialize the graphic context on an RGBA image
+	dest := image.NewRGBA(image.Rect(0, 0, Width, height))
+	gc := draw2dimg.NewGraphicContext(dest)
+
+	fontPath := os.Getenv("FONT_PATH")
+	if fontPath == "" {
+		log.Fatalln("No FONT_PATH has been set")
+	}
+
+	draw2d.SetFontFolder(fontPath)
+
+	gc.SetFontData(draw2d.FontData{
+		Name:   "luxi",
+		Family: draw2d.FontFamilyMono,
+		Style:  draw2d.FontStyleBold,
+	})
+
+	// Show the available colors on top
+	gc.Save()
+	gc.SetFillColor(color.Black)
+	gc.SetFontSize(12)
+	gc.FillStringAt(fmt.Sprintf("Colors %s, code of %d", game.AllowedColors(), game.Length), Offset, Offset+5)
+	gc.Restore()
+
+	for index, guess := range game.Guesses {
+		DrawRow(gc, index, guess, mastermind.GetFeedback(game.Code, guess))
+	}
+
+	DrawState(gc, game, height)
+
+	return dest
+}
@@ -0,0 +1,29 @@
+package draw
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/slack-games/slack-server/mastermind"
+)
+
+// Text returns the guesses with the feedback pegs, for the clients without
+// images
+func Text(game *mastermind.Mastermind) string {
+	var buffer bytes.Buffer
+
+	for i, guess := range game.Guesses {
+		feedback := mastermind.GetFeedback(game.Code, guess)
+		buffer.WriteString(fmt.Sprintf("%d. `%s` %s\n", i+1, guess, feedback))
+	}
+
+	switch game.State {
+	case mastermind.WinState:
+		buffer.WriteString(fmt.Sprintf("Cracked in %d", len(game.Guesses)))
+	case mastermind.GameOverState:
+		buffer.WriteString(fmt.Sprintf("GameOver, code `%s`", game.Code))
+	default:
+		buffer.WriteString(fmt.Sprintf("Guesses left %d", mastermind.Steps-len(game.Guesses)))
+	}
+	return buffer.String()
+}
@@ -0,0 +1,219 @@
+package mastermind
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+const (
+	// Palette holds all the peg colors, the game uses the first Colors of them
+	Palette = "RGBYOPWK"
+
+	DefaultLength = 4
+	DefaultColors = 6
+	MinLength     = 3
+	MaxLength     = 6
+	MinColors     = 4
+	MaxColors     = len(Palette)
+	// Steps is the number of guesses to crack the code
+	Steps = 10
+)
+
+const (
+	GameOverState State = 1 << iota
+	WinState
+	TurnState
+)
+
+type State int
+
+func (s State) String() string {
+	var state string
+
+	switch s {
+	case GameOverState:
+		state = "GameOver"
+	case WinState:
+		state = "Win"
+	case TurnState:
+		state = "Turn"
+	default:
+		state = "GameOver"
+	}
+
+	return state
+}
+
+func GetState(s string) State {
+	var state State
+
+	switch s {
+	case "GameOver":
+		state = GameOverState
+	case "Win":
+		state = WinState
+	case "Turn":
+		state = TurnState
+	default:
+		state = GameOverState
+	}
+	return state
+}
+
+// Feedback is the peg answer for the guess, black for the right color in
+// right position and white for the right color in wrong position
+type Feedback struct {
+	Black int
+	White int
+}
+
+func (f Feedback) String() string {
+	return fmt.Sprintf("%s%s", strings.Repeat(":black_circle:", f.Black),
+		strings.Repeat(":white_circle:", f.White))
+}
+
+// GetFeedback compares the guess against the code
+func GetFeedback(code, guess string) Feedback {
+	feedback := Feedback{}
+	var codeCount, guessCount [256]int
+
+	for i := 0; i < len(code) && i < len(guess); i++ {
+		if code[i] == guess[i] {
+			feedback.Black++
+			continue
+		}
+		codeCount[code[i]]++
+		guessCount[guess[i]]++
+	}
+
+	for color, count := range guessCount {
+		if codeCount[color] < count {
+			count = codeCount[color]
+		}
+		feedback.White += count
+	}
+	return feedback
+}
+
+// ValidateConfig checks the code length and color count limits
+func ValidateConfig(length, colors int) error {
+	if length < MinLength || length > MaxLength {
+		return fmt.Errorf("Code length should be between %d and %d", MinLength, MaxLength)
+	}
+
+	if colors < MinColors || colors > MaxColors {
+		return fmt.Errorf("Color count should be between %d and %d", MinColors, MaxColors)
+	}
+	return nil
+}
+
+// RandomCode picks the secret code from the allowed colors with the random
+// source
+func RandomCode(r *rand.Rand, length, colors int) string {
+	code := make([]byte, length)
+
+	for i := range code {
+		code[i] = Palette[r.Intn(colors)]
+	}
+	return string(code)
+}
+
+type Mastermind struct {
+	Code    string
+	Guesses []string
+	Length  int
+	Colors  int
+	State
+}
+
+// AllowedColors returns the colors in use
+func (m *Mastermind) AllowedColors() string {
+	return Palette[:m.Colors]
+}
+
+// ExampleGuess returns the guess of the code length, the allowed colors are
+// repeated when the code is longer than the palette
+func (m *Mastermind) ExampleGuess() string {
+	colors := m.AllowedColors()
+	guess := make([]byte, m.Length)
+	for i := range guess {
+		guess[i] = colors[i%len(colors)]
+	}
+	return string(guess)
+}
+
+// ValidateGuess checks the guess length and colors
+func (m *Mastermind) ValidateGuess(guess string) error {
+	if len(guess) != m.Length {
+		return fmt.Errorf("Guess should have %d pegs", m.Length)
+	}
+
+	for _, char := range guess {
+		if !strings.ContainsRune(m.AllowedColors(), char) {
+			return fmt.Errorf("Unknown color '%c', allowed colors are %s", char, m.AllowedColors())
+		}
+	}
+	return nil
+}
+
+// MakeGuess adds the guess and returns the feedback for it
+func (m *Mastermind) MakeGuess(guess string) (Feedback, error) {
+	guess = strings.ToUpper(guess)
+
+	if m.State == WinState || m.State == GameOverState {
+		return Feedback{}, errors.New("Game over could not make guess")
+	}
+
+	if err := m.ValidateGuess(guess); err != nil {
+		return Feedback{}, err
+	}
+
+	m.Guesses = append(m.Guesses, guess)
+	feedback := GetFeedback(m.Code, guess)
+
+	if feedback.Black == m.Length {
+		m.State = WinState
+	} else if len(m.Guesses) >= Steps {
+		m.State = GameOverState
+	} else {
+		m.State = TurnState
+	}
+	return feedback, nil
+}
+
+// IsConsistent checks if the code would give the same feedback for all
+// the made guesses
+func (m *Mastermind) IsConsistent(code string) bool {
+	for _, guess := range m.Guesses {
+		if GetFeedback(code, guess) != GetFeedback(m.Code, guess) {
+			return false
+		}
+	}
+	return true
+}
+
+// ConsistentCodes counts all the codes matching the feedback so far
+func (m *Mastermind) ConsistentCodes() (count int) {
+	code := make([]byte, m.Length)
+	colors := m.AllowedColors()
+
+	var walk func(position int)
+	walk = func(position int) {
+		if position == m.Length {
+			if m.IsConsistent(string(code)) {
+				count++
+			}
+			return
+		}
+
+		for i := 0; i < len(colors); i++ {
+			code[position] = colors[i]
+			walk(position + 1)
+		}
+	}
+	walk(0)
+
+	return
+}
@@ -0,0 +1,56 @@
+package mastermind
+
+import "testing"
+
+func TestGetFeedback(t *testing.T) {
+	cases := []struct {
+		code, guess  string
+		black, white int
+	}{
+		{"RGBY", "RGBY", 4, 0},
+		{"RGBY", "YBGR", 0, 4},
+		{"RRGG", "RGRG", 2, 2},
+		{"RRRG", "RGGG", 2, 0},
+		{"RGBY", "OOOO", 0, 0},
+	}
+
+	for _, c := range cases {
+		feedback := GetFeedback(c.code, c.guess)
+		if feedback.Black != c.black || feedback.White != c.white {
+			t.Error("Wrong feedback", c.code, c.guess, feedback)
+		}
+	}
+}
+
+func TestConsistentCodes(t *testing.T) {
+	game := &Mastermind{Code: "RGBY", Length: 4, Colors: 6, State: TurnState}
+
+	if count := game.ConsistentCodes(); count != 6*6*6*6 {
+		t.Error("All codes should be consistent before guesses", count)
+	}
+
+	game.MakeGuess("RGBO")
+	game.MakeGuess("RGOY")
+	if !game.IsConsistent("RGBY") {
+		t.Error("The secret code should always be consistent")
+	}
+
+	if _, err := game.MakeGuess("rgby"); err != nil || game.State != WinState {
+		t.Error("Lower case guess should win the game", err)
+	}
+}
+
+func TestExampleGuess(t *testing.T) {
+	game := &Mastermind{Length: 4, Colors: 6}
+	if game.ExampleGuess() != Palette[:4] {
+		t.Error("Example guess should take the first colors", game.ExampleGuess())
+	}
+
+	game = &Mastermind{Length: 6, Colors: 4}
+	if guess := game.ExampleGuess(); guess != Palette[:4]+Palette[:2] {
+		t.Error("Example guess longer than the colors should repeat them", guess)
+	}
+	if err := game.ValidateGuess(game.ExampleGuess()); err != nil {
+		t.Error("Example guess should be valid", err)
+	}
+}
@@ -0,0 +1,79 @@
+// Package minmax is the alpha-beta pruned minmax search of the bot players.
+// The games give the position with the legal moves of the player in turn,
+// so the passes could give the same player multiple moves in row. The
+// vendored tictactoe keeps its own AB, the library alternates the players by
+// the argument and does not depend on the server packages
+package minmax
+
+const (
+	// MaxInt maximum position value
+	MaxInt = 0xFFFF
+	// MinInt minimum position value
+	MinInt = -0xFFFF
+	// NoMove is returned when the position has no moves to search
+	NoMove = -1
+)
+
+// Position is the game state searched by the bot, the moves are the indexes
+// of the legal moves of the player in turn
+type Position interface {
+	// Moves returns the number of the legal moves
+	Moves() int
+	// Play returns the position after the move
+	Play(move int) Position
+	// Maximizing tells whether the maximizer is in turn
+	Maximizing() bool
+	// IsOver tells whether the game has ended
+	IsOver() bool
+	// Evaluate returns the value of the position for the maximizer
+	Evaluate() int
+}
+
+// AB searches the best move of the player in turn, the first move is kept
+// when none of the moves improves the bound
+func AB(position Position, depth uint8, a, b int) (score int, move int) {
+	moves := position.Moves()
+	move = NoMove
+
+	// No moves available, or depth reached
+	if position.IsOver() || moves <= 0 || depth <= 0 {
+		score = position.Evaluate()
+		return
+	}
+
+	move = 0
+	for next := 0; next < moves; next++ {
+		score, _ = AB(position.Play(next), depth-1, a, b)
+
+		if position.Maximizing() {
+			// Alpha
+			if score > a {
+				a = score
+				move = next
+			}
+		} else {
+			// Beta
+			if score < b {
+				b = score
+				move = next
+			}
+		}
+
+		if a >= b {
+			break
+		}
+	}
+
+	if position.Maximizing() {
+		score = a
+	} else {
+		score = b
+	}
+	return
+}
+
+// Best returns the best move of the player in turn with the full window
+func Best(position Position, depth uint8) int {
+	_, move := AB(position, depth, MinInt, MaxInt)
+	return move
+}
@@ -0,0 +1,57 @@
+package minmax
+
+import "testing"
+
+// tree is the position where the leaves hold the values for the maximizer
+type tree struct {
+	children   []tree
+	value      int
+	maximizing bool
+}
+
+func (t tree) Moves() int {
+	return len(t.children)
+}
+
+func (t tree) Play(move int) Position {
+	return t.children[move]
+}
+
+func (t tree) Maximizing() bool {
+	return t.maximizing
+}
+
+func (t tree) IsOver() bool {
+	return false
+}
+
+func (t tree) Evaluate() int {
+	return t.value
+}
+
+func TestAB(t *testing.T) {
+	// The opponent picks the smallest leaf, so the second move is the best
+	root := tree{maximizing: true, children: []tree{
+		{children: []tree{{value: 3}, {value: 12}}},
+		{children: []tree{{value: 5}, {value: 8}}},
+		{children: []tree{{value: 2}, {value: 14}}},
+	}}
+
+	score, move := AB(root, 2, MinInt, MaxInt)
+	if score != 5 || move != 1 {
+		t.Error("Second move should be the best", score, move)
+	}
+}
+
+func TestABKeepsFirstMove(t *testing.T) {
+	// Every move loses the same, the first one is kept
+	root := tree{maximizing: true, children: []tree{{value: MinInt}, {value: MinInt}}}
+
+	if move := Best(root, 1); move != 0 {
+		t.Error("First move should be kept", move)
+	}
+
+	if move := Best(tree{value: 1}, 1); move != NoMove {
+		t.Error("Position without moves should have no move", move)
+	}
+}
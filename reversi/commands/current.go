@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	rvsdatastore "github.com/slack-games/slack-server/reversi/datastore"
+)
+
+// CurrentCommand show the current user game state
+func CurrentCommand(db *sqlx.DB, teamID, userID string) slack.ResponseMessage {
+	log.Println("Show user current game", userID)
+	state, err := rvsdatastore.GetUserLastState(db, teamID, userID)
+
+	// No state found
+	if err != nil {
+		return slack.TextOnly("Could not get the current game, but you could `/reversi start` a new one")
+	}
+
+	game, err := rvsdatastore.CreateReversiBoard(state)
+	if err != nil {
+		log.Println("Could not create the board from state", err)
+		return slack.TextOnly("Could not read the game state")
+	}
+
+	var message string
+	if state.Mode == datastore.ForfeitMode {
+		message = forfeitMessage(state.TurnID, userID) + " For a new game `/reversi start`"
+	} else if game.IsOver() {
+		message = resultMessage(game, state.GetPlayer(userID)) + " For a new game `/reversi start`"
+	} else {
+		first, second := game.Score()
+		message = fmt.Sprintf("You play with %s, score %d - %d, your legal moves are %s - _at %s_",
+			getColor(state, userID), first, second,
+			formatSpots(game.GetLegalMoves(state.GetPlayer(userID))), state.Created.Format("15:04:05 02-01-06"))
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, "Last game state", state.StateID)},
+	}
+}
+
+// forfeitMessage tells the result of the game ended by the timeout, the
+// winner is kept in turn
+func forfeitMessage(winnerID, userID string) string {
+	if winnerID == userID {
+		return ":tada: You won by forfeit, the opponent did not move in time :tada:"
+	}
+	return "You lost by forfeit, the move was not made in time."
+}
@@ -0,0 +1,45 @@
+package commands
+
+import "github.com/slack-games/slack-client"
+
+const helpText = `
+To start a new game type _/reversi start_ or to see any existing _/reversi current_.
+You play against the bot :robot_face:, black makes the first move.
+Make move by typing _/reversi move spot_ - spot is column a-h with row 1-8.
+Example move would be _/reversi move d3_, the possible moves are marked on the board.
+When you have no legal moves your turn is passed automatically.
+
+Good luck!
+`
+
+// HelpCommand show the possible info about available commands for user
+func HelpCommand() slack.ResponseMessage {
+
+	attachments := []slack.Attachment{
+		slack.Attachment{
+			Title: "/reversi start - starts a new game",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/reversi current - show the state of current game",
+			Color: "#FF4F20",
+		},
+		slack.Attachment{
+			Title: "/reversi move [a-h][1-8] - make move on the current board",
+			Color: "#004FDD",
+		},
+		slack.Attachment{
+			Title: "/reversi boards [text|image] - show the boards as text or images",
+			Color: "#764FA5",
+		},
+		slack.Attachment{
+			Title: "/reversi help - Shows help message",
+			Color: "#76A0A0",
+		},
+	}
+
+	return slack.ResponseMessage{
+		Text:        helpText,
+		Attachments: attachments,
+	}
+}
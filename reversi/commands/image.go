@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"errors"
+	"image"
+	"log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-server/reversi"
+	rvsdatastore "github.com/slack-games/slack-server/reversi/datastore"
+	drawBoard "github.com/slack-games/slack-server/reversi/draw"
+)
+
+// GetGameImage returns the image by state
+func GetGameImage(db *sqlx.DB, stateID string) (image.Image, error) {
+	state, err := rvsdatastore.GetState(db, stateID)
+	if err != nil {
+		return nil, errors.New("Could not get the state")
+	}
+
+	game, err := rvsdatastore.CreateReversiBoard(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return drawBoard.Draw(game, reversi.GetSpot(state.LastMove)), nil
+}
+
+// GetGameText returns the board by state as text
+func GetGameText(db *sqlx.DB, stateID string) (string, error) {
+	state, err := rvsdatastore.GetState(db, stateID)
+	if err != nil {
+		return "", errors.New("Could not get the state")
+	}
+
+	game, err := rvsdatastore.CreateReversiBoard(state)
+	if err != nil {
+		return "", err
+	}
+
+	return drawBoard.Text(game), nil
+}
+
+// boardText is the text board of the attachment, shown instead of the image
+// when it could not be loaded
+func boardText(db *sqlx.DB, stateID string) string {
+	text, err := GetGameText(db, stateID)
+	if err != nil {
+		log.Println("Could not get the text board", stateID, err)
+		return "The game board"
+	}
+	return text
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/reversi"
+	rvsdatastore "github.com/slack-games/slack-server/reversi/datastore"
+)
+
+// MoveCommand makes the user move and lets the bot reply
+func MoveCommand(db *sqlx.DB, teamID, userID string, spot reversi.Spot) slack.ResponseMessage {
+	state, err := rvsdatastore.GetUserLastState(db, teamID, userID)
+
+	if err != nil {
+		// No state found
+		if err == sql.ErrNoRows {
+			return slack.TextOnly("You can not make any moves before the game has started `/reversi start`")
+		}
+		log.Println("Error could not get the user state", err)
+		return slack.TextOnly("Could not get the last game state")
+	}
+
+	// Check the game states
+	if isGameOver(state) {
+		log.Println("Game is already over")
+		return slack.TextOnly("Current game is over, but you can always start a new game `/reversi start`")
+	}
+
+	game, err := rvsdatastore.CreateReversiBoard(state)
+	if err != nil {
+		log.Println("Could not create the board from state", err)
+		return slack.TextOnly("Could not read the game state")
+	}
+
+	player := state.GetPlayer(userID)
+	if game.Turn != player {
+		return slack.TextOnly("It's not your turn, wait for the opponent move")
+	}
+
+	if err = game.MakeMove(spot.X, spot.Y); err != nil {
+		return slack.TextOnly(fmt.Sprintf("Could not make the move to %s, legal moves are %s :scream_cat:",
+			spot, formatSpots(game.GetLegalMoves(player))))
+	}
+
+	lastMove := spot
+	var botMoves []string
+
+	// Bot keeps moving as long as the user has to pass
+	for !game.IsOver() && game.Turn != player {
+		lastMove = reversi.BestMove(*game)
+		if err = game.MakeMove(lastMove.X, lastMove.Y); err != nil {
+			log.Println("Bot should be able to make move", lastMove, err)
+			break
+		}
+		botMoves = append(botMoves, lastMove.String())
+	}
+
+	newState := rvsdatastore.CreateStateFromBoard(game, state, lastMove)
+	stateID, err := rvsdatastore.NewState(db, *newState)
+	if err == datastore.ErrConflict {
+		return slack.TextOnly("The game changed meanwhile, check it with `/reversi current` and try again")
+	}
+	if err != nil {
+		log.Println("Could not save the new state", err)
+		return slack.TextOnly("Could not save the move")
+	}
+
+	message := fmt.Sprintf(":space_invader: You made move to *[%s]*", spot)
+	if len(botMoves) == 1 {
+		message += fmt.Sprintf(", bot replied *[%s]*", botMoves[0])
+	} else if len(botMoves) > 1 {
+		message += fmt.Sprintf(", you had no moves so bot played *[%s]*", strings.Join(botMoves, ", "))
+	}
+
+	if game.IsOver() {
+		message += ". " + resultMessage(game, player)
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{imageAttachment(db, "The current game state", stateID)},
+	}
+}
+
+func resultMessage(game *reversi.Reversi, player reversi.Player) string {
+	first, second := game.Score()
+	score := fmt.Sprintf("%d - %d", first, second)
+
+	switch game.Winner() {
+	case player:
+		return fmt.Sprintf(":tada: You won %s :tada:", score)
+	case reversi.UnkownPlayer:
+		return fmt.Sprintf("It's a draw %s", score)
+	}
+	return fmt.Sprintf("The bot won %s :robot_face:", score)
+}
+
+func formatSpots(spots []reversi.Spot) string {
+	moves := make([]string, len(spots))
+	for i, spot := range spots {
+		moves[i] = spot.String()
+	}
+	return strings.Join(moves, ", ")
+}
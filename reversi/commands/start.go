@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/reversi"
+	rvsdatastore "github.com/slack-games/slack-server/reversi/datastore"
+)
+
+// StartCommand is command to start a new game against the bot
+func StartCommand(db *sqlx.DB, teamID, userID string) slack.ResponseMessage {
+	var attachment slack.Attachment
+	message := "There's already existing a game, you have to finish it before starting a new"
+
+	// Try to get user last state
+	state, err := rvsdatastore.GetUserLastState(db, teamID, userID)
+
+	if err != nil && err != sql.ErrNoRows {
+		log.Println("Error could not get the user state", err)
+		return slack.TextOnly("Could not get the last game state")
+	}
+
+	if err == sql.ErrNoRows || isGameOver(state) {
+		stateID, newState := createNewState(db, teamID, userID)
+
+		message = fmt.Sprintf("Created a new game, you play with %s. To make move `/reversi move d3`.",
+			getColor(newState, userID))
+		attachment = imageAttachment(db, "New game state", stateID)
+	} else {
+		attachment = imageAttachment(db, "Last game state", state.StateID)
+	}
+
+	return slack.ResponseMessage{
+		Text:        message,
+		Attachments: []slack.Attachment{attachment},
+	}
+}
+
+func createNewState(db *sqlx.DB, teamID, userID string) (ID string, state rvsdatastore.State) {
+	seed := datastore.NewSeed()
+	game := reversi.NewReversi()
+
+	state = rvsdatastore.State{
+		TeamID:       teamID,
+		FirstUserID:  userID,
+		SecondUserID: rvsdatastore.BotUserID,
+		Seed:         seed,
+		ParentID:     "00000000-0000-0000-0000-000000000000",
+	}
+
+	lastMove := reversi.NoSpot
+
+	// Bot plays with black and makes the first move
+	if datastore.Rand(seed, 0).Intn(2) == 0 {
+		state.FirstUserID = rvsdatastore.BotUserID
+		state.SecondUserID = userID
+
+		lastMove = reversi.BestMove(game)
+		game.MakeMove(lastMove.X, lastMove.Y)
+	}
+
+	state = *rvsdatastore.CreateStateFromBoard(&game, state, lastMove)
+
+	log.Println("Create a new state")
+	ID, err := rvsdatastore.NewState(db, state)
+	if err != nil {
+		log.Fatalln("Could not create a new state", err)
+	}
+	return
+}
+
+func getColor(state rvsdatastore.State, userID string) string {
+	if state.FirstUserID == userID {
+		return ":black_circle: black"
+	}
+	return ":white_circle: white"
+}
+
+func isGameOver(state rvsdatastore.State) bool {
+	return state.Mode == fmt.Sprintf("%s", reversi.GameOverState) ||
+		state.Mode == fmt.Sprintf("%s", reversi.WinState) ||
+		state.Mode == fmt.Sprintf("%s", reversi.DrawState) ||
+		state.Mode == datastore.ForfeitMode
+}
+
+func imageAttachment(db *sqlx.DB, title, stateID string) slack.Attachment {
+	return slack.Attachment{
+		Title:    title,
+		Fallback: boardText(db, stateID),
+		ImageURL: fmt.Sprintf("%s/game/reversi/image/%s", os.Getenv("BASE_PATH"), stateID),
+		Color:    "#764FA5",
+	}
+}
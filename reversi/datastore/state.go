@@ -0,0 +1,170 @@
+package datastore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	gmsdatastore "github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/reversi"
+)
+
+// BotUserID is the AI player user
+const BotUserID = "U000000000"
+
+type State struct {
+	StateID      string    `db:"state_id"`
+	TeamID       string    `db:"team_id"`
+	State        string    `db:"state"`
+	TurnID       string    `db:"turn"`
+	Mode         string    `db:"mode"`
+	FirstUserID  string    `db:"first_user_id"`
+	SecondUserID string    `db:"second_user_id"`
+	LastMove     int       `db:"last_move"`
+	Seed         int64     `db:"seed"`
+	ParentID     string    `db:"parent_state_id"`
+	Created      time.Time `db:"created_at"`
+}
+
+func (s State) String() string {
+	return fmt.Sprintf("#[%s] - %s %s %s %s %s %s",
+		s.StateID, s.State, s.TurnID, s.Mode, s.FirstUserID, s.SecondUserID, s.Created)
+}
+
+// GetPlayer returns the player in game for the user
+func (s State) GetPlayer(userID string) reversi.Player {
+	switch userID {
+	case s.FirstUserID:
+		return reversi.FirstPlayer
+	case s.SecondUserID:
+		return reversi.SecondPlayer
+	}
+	return reversi.UnkownPlayer
+}
+
+// GetUserID returns the user playing as the player
+func (s State) GetUserID(player reversi.Player) string {
+	if player == reversi.SecondPlayer {
+		return s.SecondUserID
+	}
+	return s.FirstUserID
+}
+
+func CreateStateFromBoard(game *reversi.Reversi, state State, lastMove reversi.Spot) *State {
+	move := -1
+	if lastMove != reversi.NoSpot {
+		move = lastMove.ToMove()
+	}
+
+	return &State{
+		TeamID:       state.TeamID,
+		State:        game.GetBoardAsString(),
+		TurnID:       state.GetUserID(game.Turn),
+		Mode:         fmt.Sprintf("%s", game.State),
+		FirstUserID:  state.FirstUserID,
+		SecondUserID: state.SecondUserID,
+		LastMove:     move,
+		Seed:         state.Seed,
+		ParentID:     state.StateID,
+		Created:      time.Now(),
+	}
+}
+
+// CreateForfeitState ends the game on timeout, the player in turn loses and
+// the opponent is kept in turn as the winner
+func CreateForfeitState(state State) *State {
+	winnerID := state.FirstUserID
+	if state.TurnID == winnerID {
+		winnerID = state.SecondUserID
+	}
+
+	return &State{
+		TeamID:       state.TeamID,
+		State:        state.State,
+		TurnID:       winnerID,
+		Mode:         gmsdatastore.ForfeitMode,
+		FirstUserID:  state.FirstUserID,
+		SecondUserID: state.SecondUserID,
+		LastMove:     state.LastMove,
+		Seed:         state.Seed,
+		ParentID:     state.StateID,
+		Created:      time.Now(),
+	}
+}
+
+func CreateReversiBoard(state State) (*reversi.Reversi, error) {
+	game, err := reversi.CreateFromString(state.State, state.GetPlayer(state.TurnID),
+		reversi.GetState(state.Mode))
+
+	return &game, err
+}
+
+func GetState(db *sqlx.DB, id string) (State, error) {
+	state := State{}
+
+	err := db.Get(&state, `SELECT * FROM rvs.states WHERE state_id=$1 LIMIT 1`, id)
+	return state, err
+}
+
+func GetUserLastState(db *sqlx.DB, teamID, userID string) (State, error) {
+	state := State{}
+
+	query := `
+		SELECT *
+		FROM rvs.states
+		WHERE
+			team_id=$1 AND (first_user_id=$2 OR second_user_id=$2)
+		ORDER BY created_at DESC LIMIT 1;
+	`
+
+	err := db.Get(&state, query, teamID, userID)
+	return state, err
+}
+
+// GetStaleStates returns the running games where the player in turn has not
+// moved since before, the game is running while it's the latest one of the
+// player in turn
+func GetStaleStates(db *sqlx.DB, before time.Time) ([]State, error) {
+	states := []State{}
+
+	query := `
+		SELECT s.*
+		FROM rvs.states s
+		WHERE
+			s.mode IN ('Start', 'Turn') AND s.created_at < $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM rvs.states n
+				WHERE
+					n.team_id = s.team_id AND n.created_at > s.created_at
+					AND s.turn IN (n.first_user_id, n.second_user_id)
+			)
+	`
+
+	err := db.Select(&states, query, before)
+	return states, err
+}
+
+// NewState saves the next game state, ErrConflict is returned when the state
+// has been continued already
+func NewState(db *sqlx.DB, state State) (string, error) {
+	sql := `
+		INSERT INTO rvs.states
+			(team_id, state, turn, mode, first_user_id, second_user_id, last_move, seed, parent_state_id)
+		VALUES
+			(:team_id, :state, :turn, :mode, :first_user_id, :second_user_id, :last_move, :seed, :parent_state_id)
+		RETURNING state_id
+	`
+	var id string
+
+	rows, err := db.NamedQuery(sql, state)
+	if err != nil {
+		return id, gmsdatastore.CheckConflict(err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		rows.Scan(&id)
+	}
+	return id, gmsdatastore.CheckConflict(rows.Err())
+}
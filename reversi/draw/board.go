@@ -0,0 +1,176 @@
+package draw
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+	"os"
+
+	"github.com/llgcode/draw2d"
+	"github.com/llgcode/draw2d/draw2dimg"
+	kit "github.com/llgcode/draw2d/draw2dkit"
+	"github.com/slack-games/slack-server/reversi"
+)
+
+const (
+	Width    = 350
+	Height   = 380
+	Offset   = 25.0
+	CellSize = 37.5
+)
+
+var DefaultColor, BoardColor, RedColor color.RGBA
+var FirstColor, SecondColor color.RGBA
+
+func init() {
+	// #444444
+	DefaultColor = color.RGBA{0x44, 0x44, 0x44, 0xff}
+	// #2E7D32
+	BoardColor = color.RGBA{0x2e, 0x7d, 0x32, 0xff}
+	// #FF0000
+	RedColor = color.RGBA{0xff, 0x0, 0x0, 0xff}
+	// Black and white discs
+	FirstColor = color.RGBA{0x11, 0x11, 0x11, 0xff}
+	SecondColor = color.RGBA{0xf5, 0xf5, 0xf5, 0xff}
+}
+
+func DrawLines(gc *draw2dimg.GraphicContext) {
+	size := CellSize * reversi.Size
+
+	gc.Save()
+	gc.SetFillColor(BoardColor)
+	kit.Rectangle(gc, Offset, Offset, Offset+size, Offset+size)
+	gc.Fill()
+
+	gc.SetStrokeColor(DefaultColor)
+	gc.SetLineWidth(1)
+	for i := 0; i <= reversi.Size; i++ {
+		pos := Offset + float64(i)*CellSize
+
+		gc.MoveTo(pos, Offset)
+		gc.LineTo(pos, Offset+size)
+		gc.Stroke()
+
+		gc.MoveTo(Offset, pos)
+		gc.LineTo(Offset+size, pos)
+		gc.Stroke()
+	}
+
+	gc.SetFillColor(color.Black)
+	gc.SetFontSize(10)
+	for i := 0; i < reversi.Size; i++ {
+		pos := Offset + float64(i)*CellSize + CellSize/2
+		gc.FillStringAt(fmt.Sprintf("%c", 'a'+i), pos-3, Offset-6)
+		gc.FillStringAt(fmt.Sprintf("%d", i+1), Offset-15, pos+4)
+	}
+	gc.Restore()
+}
+
+func cellCenter(spot reversi.Spot) (float64, float64) {
+	return Offset + float64(spot.X)*CellSize + CellSize/2,
+		Offset + float64(spot.Y)*CellSize + CellSize/2
+}
+
+func DrawDiscs(gc *draw2dimg.GraphicContext, board reversi.Board) {
+	reversi.Loop(func(x, y uint8) {
+		player := reversi.Player(board.Field[x][y])
+		if player == reversi.UnkownPlayer {
+			return
+		}
+
+		xPos, yPos := cellCenter(reversi.Spot{X: x, Y: y})
+
+		gc.Save()
+		gc.SetFillColor(FirstColor)
+		if player == reversi.SecondPlayer {
+			gc.SetFillColor(SecondColor)
+		}
+		gc.SetStrokeColor(DefaultColor)
+		gc.SetLineWidth(1)
+		kit.Circle(gc, xPos, yPos, CellSize/2-4)
+		gc.FillStroke()
+		gc.Restore()
+	})
+}
+
+// DrawLastMove highlights the last made move with red ring
+func DrawLastMove(gc *draw2dimg.GraphicContext, spot reversi.Spot) {
+	if spot == reversi.NoSpot {
+		return
+	}
+
+	xPos, yPos := cellCenter(spot)
+
+	gc.Save()
+	gc.SetStrokeColor(RedColor)
+	gc.SetLineWidth(3)
+	kit.Circle(gc, xPos, yPos, CellSize/2-2)
+	gc.Stroke()
+	gc.Restore()
+}
+
+// DrawLegalMoves marks the spots where the player in turn could move
+func DrawLegalMoves(gc *draw2dimg.GraphicContext, spots []reversi.Spot) {
+	for _, spot := range spots {
+		xPos, yPos := cellCenter(spot)
+
+		gc.Save()
+		gc.SetFillColor(color.RGBA{0x00, 0x00, 0x00, 0x55})
+		kit.Circle(gc, xPos, yPos, 5)
+		gc.Fill()
+		gc.Restore()
+	}
+}
+
+func DrawScore(gc *draw2dimg.GraphicContext, game *reversi.Reversi) {
+	first, second := game.Score()
+	message := fmt.Sprintf("Black %d - %d White", first, second)
+
+	switch {
+	case game.State == reversi.DrawState:
+		message += " - Draw"
+	case game.Winner() == reversi.FirstPlayer:
+		message += " - Black wins"
+	case game.Winner() == reversi.SecondPlayer:
+		message += " - White wins"
+	}
+
+	gc.Save()
+	gc.SetFillColor(color.Black)
+	gc.SetFontSize(14)
+	gc.FillStringAt(message, Offset, Height-15)
+	gc.Restore()
+}
+
+func Draw(game *reversi.Reversi, lastMove reversi.Spot) image.Image {
+
+	// Initialize the graphic context on an RGBA image
+	dest := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	gc := draw2dimg.NewGraphicContext(dest)
+
+	fontPath := os.Getenv("FONT_PATH")
+	if fontPath == "" {
+		log.Fatalln("No FONT_PATH has been set")
+	}
+
+	draw2d.SetFontFolder(fontPath)
+
+	gc.SetFontData(draw2d.FontData{
+		Name:   "Surface",
+		Family: draw2d.FontFamilySans,
+		Style:  draw2d.FontStyleBold,
+	})
+
+	DrawLines(gc)
+	DrawDiscs(gc, game.Board)
+	DrawLastMove(gc, lastMove)
+
+	if !game.IsOver() {
+		DrawLegalMoves(gc, game.GetLegalMoves(game.Turn))
+	}
+
+	DrawScore(gc, game)
+
+	return dest
+}
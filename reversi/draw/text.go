@@ -0,0 +1,43 @@
+package draw
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/slack-games/slack-server/reversi"
+)
+
+// Text returns the board as the emoji grid with the score, for the clients
+// without images. The legal moves of the player in turn are marked
+func Text(game *reversi.Reversi) string {
+	var buffer bytes.Buffer
+
+	legal := map[reversi.Spot]bool{}
+	if !game.IsOver() {
+		for _, spot := range game.GetLegalMoves(game.Turn) {
+			legal[spot] = true
+		}
+	}
+
+	for y := uint8(0); y < reversi.Size; y++ {
+		for x := uint8(0); x < reversi.Size; x++ {
+			switch reversi.Player(game.Field[x][y]) {
+			case reversi.FirstPlayer:
+				buffer.WriteString(":black_circle:")
+			case reversi.SecondPlayer:
+				buffer.WriteString(":white_circle:")
+			default:
+				if legal[reversi.Spot{X: x, Y: y}] {
+					buffer.WriteString(":small_orange_diamond:")
+				} else {
+					buffer.WriteString(":green_square:")
+				}
+			}
+		}
+		buffer.WriteString(fmt.Sprintf(" %d\n", y+1))
+	}
+
+	first, second := game.Score()
+	buffer.WriteString(fmt.Sprintf("Columns a-h, black %d - %d white", first, second))
+	return buffer.String()
+}
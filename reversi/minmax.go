@@ -0,0 +1,105 @@
+package reversi
+
+import "github.com/slack-games/slack-server/minmax"
+
+const (
+	// MaxInt maximum board value
+	MaxInt = minmax.MaxInt
+	// MinInt minimum board value
+	MinInt = minmax.MinInt
+	// Depth default search depth for the bot
+	Depth = 4
+)
+
+// Positional weights, corners are the most valuable and the spots next to
+// them give the corner away
+var weights = [Size][Size]int{
+	{100, -20, 10, 5, 5, 10, -20, 100},
+	{-20, -50, -2, -2, -2, -2, -50, -20},
+	{10, -2, -1, -1, -1, -1, -2, 10},
+	{5, -2, -1, -1, -1, -1, -2, 5},
+	{5, -2, -1, -1, -1, -1, -2, 5},
+	{10, -2, -1, -1, -1, -1, -2, 10},
+	{-20, -50, -2, -2, -2, -2, -50, -20},
+	{100, -20, 10, 5, 5, 10, -20, 100},
+}
+
+func evaluateBoard(game Reversi, maximizer Player) int {
+	first, second := game.Score()
+	discs := first - second
+	if maximizer == SecondPlayer {
+		discs = -discs
+	}
+
+	if game.IsOver() {
+		switch game.Winner() {
+		case maximizer:
+			return MaxInt/2 + discs
+		case maximizer.Opponent():
+			return MinInt/2 + discs
+		}
+		return 0
+	}
+
+	score := 0
+	Loop(func(x, y uint8) {
+		switch Player(game.Field[x][y]) {
+		case maximizer:
+			score += weights[x][y]
+		case maximizer.Opponent():
+			score -= weights[x][y]
+		}
+	})
+
+	// Mobility, more moves available gives more options later
+	mobility := len(game.GetLegalMoves(maximizer)) - len(game.GetLegalMoves(maximizer.Opponent()))
+
+	return score + 5*mobility
+}
+
+// position is the game searched by the bot, the legal moves are kept for
+// the move indexes
+type position struct {
+	game      Reversi
+	maximizer Player
+	moves     []Spot
+}
+
+func newPosition(game Reversi, maximizer Player) *position {
+	return &position{game: game, maximizer: maximizer, moves: game.GetLegalMoves(game.Turn)}
+}
+
+func (p *position) Moves() int {
+	return len(p.moves)
+}
+
+// Play makes the move, the player in turn is taken from the game as passes
+// could give the same player multiple moves in row
+func (p *position) Play(move int) minmax.Position {
+	newGame := p.game
+	newGame.MakeMove(p.moves[move].X, p.moves[move].Y)
+	return newPosition(newGame, p.maximizer)
+}
+
+func (p *position) Maximizing() bool {
+	return p.game.Turn == p.maximizer
+}
+
+func (p *position) IsOver() bool {
+	return p.game.IsOver()
+}
+
+func (p *position) Evaluate() int {
+	return evaluateBoard(p.game, p.maximizer)
+}
+
+// BestMove returns the bot move for the current turn player
+func BestMove(game Reversi) Spot {
+	position := newPosition(game, game.Turn)
+
+	move := minmax.Best(position, Depth)
+	if move == minmax.NoMove {
+		return NoSpot
+	}
+	return position.moves[move]
+}
@@ -0,0 +1,347 @@
+package reversi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Size of the board, height is also same
+	Size = 8
+)
+
+const (
+	GameOverState State = 1 << iota
+	DrawState
+	WinState
+	TurnState
+	StartState
+)
+
+const (
+	UnkownPlayer Player = iota
+	// FirstPlayer plays with the black discs and makes the first move
+	FirstPlayer
+	// SecondPlayer plays with the white discs
+	SecondPlayer
+)
+
+// NoSpot is used when there's no move available
+var NoSpot = Spot{0xFF, 0xFF}
+
+// All the directions to check for the flips
+var directions = [][2]int{
+	{-1, -1}, {0, -1}, {1, -1},
+	{-1, 0}, {1, 0},
+	{-1, 1}, {0, 1}, {1, 1},
+}
+
+type Player uint8
+
+type State int
+
+func (s State) String() string {
+	var state string
+
+	switch s {
+	case GameOverState:
+		state = "GameOver"
+
+	case WinState:
+		state = "Win"
+
+	case DrawState:
+		state = "Draw"
+
+	case TurnState:
+		state = "Turn"
+
+	case StartState:
+		state = "Start"
+
+	default:
+		state = "Unkown"
+	}
+
+	return state
+}
+
+func GetState(s string) State {
+	var state State
+
+	switch s {
+	case "GameOver":
+		state = GameOverState
+	case "Win":
+		state = WinState
+	case "Draw":
+		state = DrawState
+	case "Turn":
+		state = TurnState
+	case "Start":
+		state = StartState
+	default:
+		state = GameOverState
+	}
+	return state
+}
+
+// Opponent returns the other player
+func (p Player) Opponent() Player {
+	if p == FirstPlayer {
+		return SecondPlayer
+	}
+	return FirstPlayer
+}
+
+// Spot is a board coordinate, X is the column (a-h) and Y the row (1-8)
+type Spot struct {
+	X, Y uint8
+}
+
+func (s Spot) String() string {
+	if s == NoSpot {
+		return "-"
+	}
+	return fmt.Sprintf("%c%d", 'a'+s.X, s.Y+1)
+}
+
+// ToMove converts the spot into the board string index
+func (s Spot) ToMove() int {
+	return int(s.Y)*Size + int(s.X)
+}
+
+// ParseSpot converts the notation like "d3" into the spot
+func ParseSpot(value string) (Spot, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if len(value) != 2 {
+		return NoSpot, fmt.Errorf("Invalid spot '%s'", value)
+	}
+
+	if value[0] < 'a' || value[0] >= 'a'+Size || value[1] < '1' || value[1] >= '1'+Size {
+		return NoSpot, fmt.Errorf("Invalid spot '%s'", value)
+	}
+
+	return Spot{value[0] - 'a', value[1] - '1'}, nil
+}
+
+// GetSpot converts the board string index into spot, negative index is
+// considered as no spot
+func GetSpot(index int) Spot {
+	if index < 0 || index >= Size*Size {
+		return NoSpot
+	}
+	return Spot{uint8(index % Size), uint8(index / Size)}
+}
+
+type Board struct {
+	Field [Size][Size]uint8
+}
+
+type Reversi struct {
+	Board
+	Turn Player
+	// Passes counts the turns skipped in the last move due to no legal moves
+	Passes int
+	State
+}
+
+// NewReversi creates the game with the four discs in the center, black
+// starts the game
+func NewReversi() Reversi {
+	game := Reversi{Turn: FirstPlayer, State: StartState}
+
+	game.Field[3][3] = uint8(SecondPlayer)
+	game.Field[4][4] = uint8(SecondPlayer)
+	game.Field[4][3] = uint8(FirstPlayer)
+	game.Field[3][4] = uint8(FirstPlayer)
+
+	return game
+}
+
+func (r *Reversi) IsOver() bool {
+	return r.State == GameOverState || r.State == WinState || r.State == DrawState
+}
+
+// flips returns the opponent discs which would be flipped by the move
+func (r *Reversi) flips(x, y uint8, player Player) []Spot {
+	var spots []Spot
+
+	if r.Field[x][y] != 0 {
+		return spots
+	}
+
+	for _, direction := range directions {
+		var line []Spot
+		cx, cy := int(x)+direction[0], int(y)+direction[1]
+
+		for cx >= 0 && cx < Size && cy >= 0 && cy < Size {
+			cell := Player(r.Field[cx][cy])
+
+			if cell == player.Opponent() {
+				line = append(line, Spot{uint8(cx), uint8(cy)})
+			} else {
+				if cell == player && len(line) > 0 {
+					spots = append(spots, line...)
+				}
+				break
+			}
+			cx, cy = cx+direction[0], cy+direction[1]
+		}
+	}
+	return spots
+}
+
+// GetLegalMoves returns all the spots where the player could move
+func (r *Reversi) GetLegalMoves(player Player) []Spot {
+	var spots []Spot
+
+	Loop(func(x, y uint8) {
+		if len(r.flips(x, y, player)) > 0 {
+			spots = append(spots, Spot{x, y})
+		}
+	})
+	return spots
+}
+
+// IsLegalMove checks if the current turn player could move to the spot
+func (r *Reversi) IsLegalMove(x, y uint8) bool {
+	if x >= Size || y >= Size {
+		return false
+	}
+	return len(r.flips(x, y, r.Turn)) > 0
+}
+
+// MakeMove places the disc for the current player, flips the opponent discs
+// and gives the turn to opponent. When opponent has no legal moves the turn
+// is passed back, when neither of the players could move the game is over
+func (r *Reversi) MakeMove(x, y uint8) error {
+	if r.IsOver() {
+		return errors.New("Game over could not make move")
+	}
+
+	if x >= Size || y >= Size {
+		return fmt.Errorf("Move %d - %d is out of board", x, y)
+	}
+
+	flips := r.flips(x, y, r.Turn)
+	if len(flips) == 0 {
+		return fmt.Errorf("Illegal move to %s", Spot{x, y})
+	}
+
+	r.Field[x][y] = uint8(r.Turn)
+	for _, spot := range flips {
+		r.Field[spot.X][spot.Y] = uint8(r.Turn)
+	}
+
+	r.Passes = 0
+	r.State = TurnState
+	r.Turn = r.Turn.Opponent()
+	r.checkPass()
+
+	return nil
+}
+
+// checkPass skips the turn of the player without legal moves, ends the game
+// when both of the players are stuck
+func (r *Reversi) checkPass() {
+	if len(r.GetLegalMoves(r.Turn)) > 0 {
+		return
+	}
+
+	if len(r.GetLegalMoves(r.Turn.Opponent())) > 0 {
+		r.Passes++
+		r.Turn = r.Turn.Opponent()
+		return
+	}
+
+	first, second := r.Score()
+	switch {
+	case first > second:
+		r.State = WinState
+		r.Turn = FirstPlayer
+	case second > first:
+		r.State = WinState
+		r.Turn = SecondPlayer
+	default:
+		r.State = DrawState
+	}
+}
+
+// Winner returns the winner player when the game is won, on the win state
+// turn holds the winner
+func (r *Reversi) Winner() Player {
+	if r.State != WinState {
+		return UnkownPlayer
+	}
+	return r.Turn
+}
+
+// Score counts the discs of both of the players
+func (r *Reversi) Score() (first, second int) {
+	Loop(func(x, y uint8) {
+		switch Player(r.Field[x][y]) {
+		case FirstPlayer:
+			first++
+		case SecondPlayer:
+			second++
+		}
+	})
+	return
+}
+
+func (r *Reversi) GetBoardAsString() string {
+	var state bytes.Buffer
+
+	Loop(func(x, y uint8) {
+		state.WriteString(strconv.Itoa(int(r.Field[x][y])))
+	})
+
+	return state.String()
+}
+
+// CreateFromString restores the board from the board string
+func CreateFromString(value string, turn Player, state State) (Reversi, error) {
+	game := Reversi{Turn: turn, State: state}
+
+	if len(value) != Size*Size {
+		return game, fmt.Errorf("Invalid board length %d", len(value))
+	}
+
+	for i := 0; i < len(value); i++ {
+		num, err := strconv.ParseInt(value[i:i+1], 10, 8)
+		if err != nil || num > int64(SecondPlayer) {
+			return game, fmt.Errorf("Invalid board cell '%c'", value[i])
+		}
+
+		spot := GetSpot(i)
+		game.Field[spot.X][spot.Y] = uint8(num)
+	}
+	return game, nil
+}
+
+func (r Reversi) String() string {
+	board := ""
+	for y := 0; y < Size; y++ {
+		for x := 0; x < Size; x++ {
+			board += fmt.Sprintf("%d", r.Field[x][y])
+		}
+
+		board += "\n"
+	}
+	return board
+}
+
+// Loop helper function to replace the repeating double loop pattern
+func Loop(fn func(uint8, uint8)) {
+	var x, y uint8
+
+	for y = 0; y < Size; y++ {
+		for x = 0; x < Size; x++ {
+			fn(x, y)
+		}
+	}
+}
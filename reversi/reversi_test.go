@@ -0,0 +1,57 @@
+package reversi
+
+import "testing"
+
+func TestLegalMovesOnStart(t *testing.T) {
+	game := NewReversi()
+	moves := game.GetLegalMoves(FirstPlayer)
+
+	if len(moves) != 4 {
+		t.Fatal("Black should have 4 moves on start", moves)
+	}
+
+	if err := game.MakeMove(3, 2); err != nil {
+		t.Fatal("Move to d3 should be legal", err)
+	}
+
+	first, second := game.Score()
+	if first != 4 || second != 1 {
+		t.Error("Move to d3 should flip one disc", first, second)
+	}
+
+	if game.Turn != SecondPlayer {
+		t.Error("Turn should switch to white")
+	}
+
+	if err := game.MakeMove(0, 0); err == nil {
+		t.Error("Move to a1 should be illegal")
+	}
+}
+
+func TestPassAndGameOver(t *testing.T) {
+	// Black could only move to h1, after that neither player could move
+	board := "12222220" + "11111111" + "11111111" + "11111111" +
+		"11111111" + "11111111" + "11111111" + "11111111"
+
+	game, err := CreateFromString(board, FirstPlayer, TurnState)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = game.MakeMove(7, 0); err != nil {
+		t.Fatal("Move to h1 should be legal", err)
+	}
+
+	if game.State != WinState || game.Winner() != FirstPlayer {
+		t.Error("Black should win the game", game.State, game.Winner())
+	}
+}
+
+func TestBotMove(t *testing.T) {
+	game := NewReversi()
+	spot := BestMove(game)
+
+	if !game.IsLegalMove(spot.X, spot.Y) {
+		t.Error("Bot should make a legal move", spot)
+	}
+}
@@ -0,0 +1,225 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	hngdatastore "github.com/slack-games/slack-hangman/datastore"
+	btsdatastore "github.com/slack-games/slack-server/battleship/datastore"
+	chkdatastore "github.com/slack-games/slack-server/checkers/datastore"
+	"github.com/slack-games/slack-server/datastore"
+	dabdatastore "github.com/slack-games/slack-server/dots/datastore"
+	mmsdatastore "github.com/slack-games/slack-server/mastermind/datastore"
+	rvsdatastore "github.com/slack-games/slack-server/reversi/datastore"
+	tttdatastore "github.com/slack-games/slack-tictactoe/datastore"
+)
+
+// Games are the games checked by the scheduler
+var Games = []Game{
+	{
+		Name:    "tic-tac-toe",
+		Command: "/ttt",
+		Stale: func(db *sqlx.DB, before time.Time) ([]Stale, error) {
+			states, err := tttdatastore.GetStaleStates(db, before)
+
+			stale := make([]Stale, len(states))
+			for i, state := range states {
+				stale[i] = Stale{
+					StateID:     state.StateID,
+					TeamID:      state.TeamID,
+					UserID:      state.TurnID,
+					OpponentIDs: opponents(state.TurnID, state.FirstUserID, state.SecondUserID),
+					Created:     state.Created,
+				}
+			}
+			return stale, err
+		},
+		Forfeit: func(db *sqlx.DB, stateID string) error {
+			state, err := tttdatastore.GetState(db, stateID)
+			if err != nil {
+				return err
+			}
+
+			_, err = tttdatastore.NewState(db, *tttdatastore.CreateForfeitState(state))
+			return err
+		},
+	},
+	{
+		Name:    "hangman",
+		Command: "/hng",
+		Stale: func(db *sqlx.DB, before time.Time) ([]Stale, error) {
+			states, err := hngdatastore.GetStaleStates(db, before)
+
+			stale := make([]Stale, len(states))
+			for i, state := range states {
+				stale[i] = Stale{
+					StateID: state.StateID,
+					TeamID:  state.TeamID,
+					UserID:  state.UserID,
+					Created: state.Created,
+				}
+			}
+			return stale, err
+		},
+		Forfeit: func(db *sqlx.DB, stateID string) error {
+			state, err := hngdatastore.GetState(db, stateID)
+			if err != nil {
+				return err
+			}
+
+			_, err = hngdatastore.NewState(db, hngdatastore.CreateForfeitState(state))
+			return err
+		},
+	},
+	{
+		Name:    "battleship",
+		Command: "/battleship",
+		Stale: func(db *sqlx.DB, before time.Time) ([]Stale, error) {
+			states, err := btsdatastore.GetStaleStates(db, before)
+
+			stale := make([]Stale, len(states))
+			for i, state := range states {
+				stale[i] = Stale{
+					StateID:     state.StateID,
+					TeamID:      state.TeamID,
+					UserID:      state.GetWaitingID(),
+					OpponentIDs: opponents(state.GetWaitingID(), state.FirstUserID, state.SecondUserID),
+					Created:     state.Created,
+				}
+			}
+			return stale, err
+		},
+		Forfeit: func(db *sqlx.DB, stateID string) error {
+			state, err := btsdatastore.GetState(db, stateID)
+			if err != nil {
+				return err
+			}
+
+			_, err = btsdatastore.NewState(db, *btsdatastore.CreateForfeitState(state))
+			return err
+		},
+	},
+	{
+		Name:    "reversi",
+		Command: "/reversi",
+		Stale: func(db *sqlx.DB, before time.Time) ([]Stale, error) {
+			states, err := rvsdatastore.GetStaleStates(db, before)
+
+			stale := make([]Stale, len(states))
+			for i, state := range states {
+				stale[i] = Stale{
+					StateID:     state.StateID,
+					TeamID:      state.TeamID,
+					UserID:      state.TurnID,
+					OpponentIDs: opponents(state.TurnID, state.FirstUserID, state.SecondUserID),
+					Created:     state.Created,
+				}
+			}
+			return stale, err
+		},
+		Forfeit: func(db *sqlx.DB, stateID string) error {
+			state, err := rvsdatastore.GetState(db, stateID)
+			if err != nil {
+				return err
+			}
+
+			_, err = rvsdatastore.NewState(db, *rvsdatastore.CreateForfeitState(state))
+			return err
+		},
+	},
+	{
+		Name:    "checkers",
+		Command: "/checkers",
+		Stale: func(db *sqlx.DB, before time.Time) ([]Stale, error) {
+			states, err := chkdatastore.GetStaleStates(db, before)
+
+			stale := make([]Stale, len(states))
+			for i, state := range states {
+				stale[i] = Stale{
+					StateID:     state.StateID,
+					TeamID:      state.TeamID,
+					UserID:      state.TurnID,
+					OpponentIDs: opponents(state.TurnID, state.FirstUserID, state.SecondUserID),
+					Created:     state.Created,
+				}
+			}
+			return stale, err
+		},
+		Forfeit: func(db *sqlx.DB, stateID string) error {
+			state, err := chkdatastore.GetState(db, stateID)
+			if err != nil {
+				return err
+			}
+
+			_, err = chkdatastore.NewState(db, *chkdatastore.CreateForfeitState(state))
+			return err
+		},
+	},
+	{
+		Name:    "mastermind",
+		Command: "/mastermind",
+		Stale: func(db *sqlx.DB, before time.Time) ([]Stale, error) {
+			states, err := mmsdatastore.GetStaleStates(db, before)
+
+			stale := make([]Stale, len(states))
+			for i, state := range states {
+				stale[i] = Stale{
+					StateID: state.StateID,
+					TeamID:  state.TeamID,
+					UserID:  state.UserID,
+					Created: state.Created,
+				}
+			}
+			return stale, err
+		},
+		Forfeit: func(db *sqlx.DB, stateID string) error {
+			state, err := mmsdatastore.GetState(db, stateID)
+			if err != nil {
+				return err
+			}
+
+			_, err = mmsdatastore.NewState(db, *mmsdatastore.CreateForfeitState(state))
+			return err
+		},
+	},
+	{
+		Name:    "dots and boxes",
+		Command: "/dots",
+		Stale: func(db *sqlx.DB, before time.Time) ([]Stale, error) {
+			states, err := dabdatastore.GetStaleStates(db, before)
+
+			stale := make([]Stale, len(states))
+			for i, state := range states {
+				stale[i] = Stale{
+					StateID:     state.StateID,
+					TeamID:      state.TeamID,
+					UserID:      state.TurnID,
+					OpponentIDs: opponents(state.TurnID, state.GetPlayers()...),
+					Created:     state.Created,
+				}
+			}
+			return stale, err
+		},
+		Forfeit: func(db *sqlx.DB, stateID string) error {
+			state, err := dabdatastore.GetState(db, stateID)
+			if err != nil {
+				return err
+			}
+
+			_, err = dabdatastore.NewState(db, *dabdatastore.CreateForfeitState(state))
+			return err
+		},
+	},
+}
+
+// opponents returns the other players of the game to tell about the forfeit,
+// the bot is left out
+func opponents(userID string, playerIDs ...string) []string {
+	var opponentIDs []string
+	for _, playerID := range playerIDs {
+		if playerID != "" && playerID != userID && playerID != datastore.BotUserID {
+			opponentIDs = append(opponentIDs, playerID)
+		}
+	}
+	return opponentIDs
+}
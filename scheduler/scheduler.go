@@ -0,0 +1,222 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/server"
+)
+
+// LockKey is the Postgres advisory lock key shared by all the server
+// instances, only one of them scans the games at the time
+const LockKey = 0x5C4ED
+
+// Config holds the scheduler timings
+type Config struct {
+	// Interval between the scans
+	Interval time.Duration
+	// RemindAfter is the time player could wait before the reminder is sent
+	RemindAfter time.Duration
+	// Timeout is the time after which the game is forfeited
+	Timeout time.Duration
+}
+
+// Stale is the game state waiting for the player, the opponents are told
+// when the game is forfeited
+type Stale struct {
+	StateID     string
+	TeamID      string
+	UserID      string
+	OpponentIDs []string
+	Created     time.Time
+}
+
+// Game describes how to find and forfeit the stale games
+type Game struct {
+	Name string
+	// Command is shown in the reminder to continue the game
+	Command string
+	Stale   func(db *sqlx.DB, before time.Time) ([]Stale, error)
+	Forfeit func(db *sqlx.DB, stateID string) error
+}
+
+// Notifier sends the message to the user
+type Notifier interface {
+	Notify(teamID, userID, text string) error
+}
+
+// LogNotifier only logs the messages, used when there's no way to reach the
+// users
+type LogNotifier struct{}
+
+// Notify logs the message
+func (n LogNotifier) Notify(teamID, userID, text string) error {
+	log.Println("Notify", teamID, userID, text)
+	return nil
+}
+
+// SlackNotifier sends the message as direct message from the team bot
+type SlackNotifier struct {
+	Context server.Context
+}
+
+// Notify posts the message to the user
+func (n SlackNotifier) Notify(teamID, userID, text string) error {
+	client, err := n.Context.SlackClient(teamID)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.PostMessage(userID, slack.TextOnly(text))
+	return err
+}
+
+// Scheduler scans periodically for the games where the player has walked
+// away, reminds the player and later forfeits the game
+type Scheduler struct {
+	Db *sqlx.DB
+	Config
+	Notifier Notifier
+	Games    []Game
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New creates the scheduler for all the games with the turn timeouts
+func New(db *sqlx.DB, config Config, notifier Notifier) *Scheduler {
+	return &Scheduler{
+		Db:       db,
+		Config:   config,
+		Notifier: notifier,
+		Games:    Games,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs the scans in background until stopped
+func (s *Scheduler) Start() {
+	go s.run()
+}
+
+// Stop waits for the running scan to finish and stops the scheduler
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	<-s.done
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			log.Println("Scheduler stopped")
+			return
+
+		case <-ticker.C:
+			if err := s.Scan(); err != nil {
+				log.Println("Scheduler scan failed", err)
+			}
+		}
+	}
+}
+
+// Scan checks all the games once, the advisory lock is held in transaction
+// so the other instances skip the scan meanwhile
+func (s *Scheduler) Scan() error {
+	tx, err := s.Db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err = tx.Get(&locked, `SELECT pg_try_advisory_xact_lock($1)`, LockKey); err != nil {
+		return err
+	}
+
+	if !locked {
+		log.Println("Scheduler lock is held by another instance")
+		return nil
+	}
+
+	now := time.Now()
+	for _, game := range s.Games {
+		if err = s.scanGame(game, now); err != nil {
+			log.Println("Could not scan game", game.Name, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *Scheduler) scanGame(game Game, now time.Time) error {
+	states, err := game.Stale(s.Db, now.Add(-s.RemindAfter))
+	if err != nil {
+		return err
+	}
+
+	for _, state := range states {
+		if state.Created.Before(now.Add(-s.Timeout)) {
+			s.forfeit(game, state)
+		} else {
+			s.remind(game, state)
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) forfeit(game Game, state Stale) {
+	log.Println("Forfeit the game", game.Name, state.StateID)
+
+	err := game.Forfeit(s.Db, state.StateID)
+	if err == datastore.ErrConflict {
+		log.Println("The game was played meanwhile, no forfeit", state.StateID)
+		return
+	}
+	if err != nil {
+		log.Println("Could not forfeit the game", state.StateID, err)
+		return
+	}
+
+	text := fmt.Sprintf("Your %s game timed out and is over by forfeit, start a new one `%s start`",
+		game.Name, game.Command)
+	if err := s.Notifier.Notify(state.TeamID, state.UserID, text); err != nil {
+		log.Println("Could not notify the user", state.UserID, err)
+	}
+
+	text = fmt.Sprintf("Your %s game is over, <@%s> did not make the move in time and forfeited. "+
+		"Start a new one `%s start`", game.Name, state.UserID, game.Command)
+	for _, opponentID := range state.OpponentIDs {
+		if err := s.Notifier.Notify(state.TeamID, opponentID, text); err != nil {
+			log.Println("Could not notify the opponent", opponentID, err)
+		}
+	}
+}
+
+func (s *Scheduler) remind(game Game, state Stale) {
+	created, err := datastore.NewReminder(s.Db, state.StateID, state.TeamID, state.UserID)
+	if err != nil {
+		log.Println("Could not save the reminder", state.StateID, err)
+		return
+	}
+
+	// Already reminded
+	if !created {
+		return
+	}
+
+	text := fmt.Sprintf("Your %s game is waiting for your turn `%s current`", game.Name, game.Command)
+	if err := s.Notifier.Notify(state.TeamID, state.UserID, text); err != nil {
+		log.Println("Could not notify the user", state.UserID, err)
+	}
+}
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-server/datastore"
+)
+
+type recordNotifier struct {
+	users []string
+}
+
+func (n *recordNotifier) Notify(teamID, userID, text string) error {
+	n.users = append(n.users, userID)
+	return nil
+}
+
+func TestForfeitAfterTimeout(t *testing.T) {
+	now := time.Now()
+	var forfeited []string
+
+	game := Game{
+		Name:    "test",
+		Command: "/test",
+		Stale: func(db *sqlx.DB, before time.Time) ([]Stale, error) {
+			return []Stale{{StateID: "old", TeamID: "T1", UserID: "U1", OpponentIDs: []string{"U2"},
+				Created: now.Add(-3 * time.Hour)}}, nil
+		},
+		Forfeit: func(db *sqlx.DB, stateID string) error {
+			forfeited = append(forfeited, stateID)
+			return nil
+		},
+	}
+
+	notifier := &recordNotifier{}
+	s := New(nil, Config{time.Minute, time.Hour, 2 * time.Hour}, notifier)
+
+	if err := s.scanGame(game, now); err != nil {
+		t.Fatal("Scan failed", err)
+	}
+
+	if len(forfeited) != 1 || forfeited[0] != "old" {
+		t.Error("Timed out game should be forfeited", forfeited)
+	}
+
+	if len(notifier.users) != 2 || notifier.users[0] != "U1" || notifier.users[1] != "U2" {
+		t.Error("Player and the opponent should be notified about the forfeit", notifier.users)
+	}
+}
+
+func TestOpponents(t *testing.T) {
+	if opponentIDs := opponents("U1", "U1", "U2"); len(opponentIDs) != 1 || opponentIDs[0] != "U2" {
+		t.Error("Other player should be the opponent", opponentIDs)
+	}
+
+	if opponentIDs := opponents("U1", datastore.BotUserID, "U1"); len(opponentIDs) != 0 {
+		t.Error("Bot should not be notified", opponentIDs)
+	}
+}
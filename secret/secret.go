@@ -0,0 +1,65 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+// KeySize is the AES-256 key size in bytes
+const KeySize = 32
+
+// Box encrypts the values with AES-GCM, the random nonce is prepended to
+// the sealed value
+type Box struct {
+	aead cipher.AEAD
+}
+
+// NewBox creates the box with the 32 byte key
+func NewBox(key []byte) (*Box, error) {
+	if len(key) != KeySize {
+		return nil, errors.New("Secret key should be 32 bytes")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead}, nil
+}
+
+// NewBoxFromHex creates the box with the hex encoded key
+func NewBoxFromHex(key string) (*Box, error) {
+	value, err := hex.DecodeString(key)
+	if err != nil {
+		return nil, err
+	}
+	return NewBox(value)
+}
+
+// Seal encrypts the value
+func (b *Box) Seal(value []byte) ([]byte, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return b.aead.Seal(nonce, nonce, value, nil), nil
+}
+
+// Open decrypts the sealed value
+func (b *Box) Open(sealed []byte) ([]byte, error) {
+	if len(sealed) < b.aead.NonceSize() {
+		return nil, errors.New("Sealed value is too short")
+	}
+
+	nonce, value := sealed[:b.aead.NonceSize()], sealed[b.aead.NonceSize():]
+	return b.aead.Open(nil, nonce, value, nil)
+}
@@ -0,0 +1,52 @@
+package secret
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSealAndOpen(t *testing.T) {
+	box, err := NewBoxFromHex("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	if err != nil {
+		t.Fatal("Could not create the box", err)
+	}
+
+	sealed, _ := box.Seal([]byte("xoxb-token"))
+	if bytes.Contains(sealed, []byte("xoxb-token")) {
+		t.Error("Sealed value should not contain the plain value")
+	}
+
+	value, err := box.Open(sealed)
+	if err != nil || string(value) != "xoxb-token" {
+		t.Error("Opened value should match", string(value), err)
+	}
+
+	sealed[len(sealed)-1] ^= 0xFF
+	if _, err := box.Open(sealed); err == nil {
+		t.Error("Tampered value should not open")
+	}
+
+	if _, err := NewBoxFromHex("0011"); err == nil {
+		t.Error("Short key should not be allowed")
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	signer := NewSigner(DeriveKey([]byte("key"), "cookies"))
+	now := time.Now()
+
+	signed := signer.Sign("state", now.Add(time.Minute))
+	if value, err := signer.Verify(signed, now); err != nil || value != "state" {
+		t.Error("Signed value should be verified", value, err)
+	}
+
+	if _, err := signer.Verify(signed, now.Add(2*time.Minute)); err != ErrExpired {
+		t.Error("Expired value should not be verified", err)
+	}
+
+	other := NewSigner(DeriveKey([]byte("key"), "tokens"))
+	if _, err := other.Verify(signed, now); err != ErrInvalidSignature {
+		t.Error("Value signed with other key should not be verified", err)
+	}
+}
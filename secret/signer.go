@@ -0,0 +1,93 @@
+package secret
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSignature is returned for the tampered or malformed values
+	ErrInvalidSignature = errors.New("Invalid signature")
+	// ErrExpired is returned for the values signed with the past expiry
+	ErrExpired = errors.New("Signed value has expired")
+)
+
+// Signer signs the short lived values with HMAC-SHA256, like cookies
+type Signer struct {
+	key []byte
+}
+
+// NewSigner creates the signer with the key
+func NewSigner(key []byte) *Signer {
+	return &Signer{key}
+}
+
+// DeriveKey creates the separate key for the purpose, so the same secret
+// could be used for the encryption and signing
+func DeriveKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// RandomToken returns the URL safe random token from n random bytes
+func RandomToken(n int) (string, error) {
+	value := make([]byte, n)
+	if _, err := rand.Read(value); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(value), nil
+}
+
+func (s *Signer) mac(payload string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// Sign returns the value with the expiry time and signature
+func (s *Signer) Sign(value string, expires time.Time) string {
+	payload := value + "|" + strconv.FormatInt(expires.Unix(), 10)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(s.mac(payload))
+}
+
+// Verify checks the signature and expiry, returns the signed value
+func (s *Signer) Verify(signed string, now time.Time) (string, error) {
+	parts := strings.Split(signed, ".")
+	if len(parts) != 2 {
+		return "", ErrInvalidSignature
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, s.mac(string(payload))) {
+		return "", ErrInvalidSignature
+	}
+
+	separator := strings.LastIndex(string(payload), "|")
+	if separator < 0 {
+		return "", ErrInvalidSignature
+	}
+
+	expires, err := strconv.ParseInt(string(payload[separator+1:]), 10, 64)
+	if err != nil {
+		return "", ErrInvalidSignature
+	}
+
+	if now.Unix() > expires {
+		return "", ErrExpired
+	}
+	return string(payload[:separator]), nil
+}
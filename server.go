@@ -1,9 +1,14 @@
 package main
 
 import (
+	"encoding/hex"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"gopkg.in/bluesuncorp/validator.v8"
 
@@ -13,6 +18,8 @@ import (
 	_ "github.com/joho/godotenv/autoload"
 	_ "github.com/lib/pq"
 	"github.com/slack-games/slack-server/controller"
+	"github.com/slack-games/slack-server/scheduler"
+	"github.com/slack-games/slack-server/secret"
 	"github.com/slack-games/slack-server/server"
 )
 
@@ -29,6 +36,24 @@ func Router(context server.Context) *mux.Router {
 	tictactoeController := controller.TictactoeController{Context: context}
 	tictactoeController.Register(gameRouter)
 
+	battleshipController := controller.BattleshipController{Context: context}
+	battleshipController.Register(gameRouter)
+
+	reversiController := controller.ReversiController{Context: context}
+	reversiController.Register(gameRouter)
+
+	checkersController := controller.CheckersController{Context: context}
+	checkersController.Register(gameRouter)
+
+	mastermindController := controller.MastermindController{Context: context}
+	mastermindController.Register(gameRouter)
+
+	dotsController := controller.DotsController{Context: context}
+	dotsController.Register(gameRouter)
+
+	eventsController := controller.EventsController{Context: context}
+	eventsController.Register(router)
+
 	loginController := controller.LoginController{Context: context}
 	loginController.Register(router)
 
@@ -41,6 +66,36 @@ func Router(context server.Context) *mux.Router {
 	return router
 }
 
+// getDuration reads the duration from env variable, like "30m" or "24h"
+func getDuration(name string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalln("Invalid duration", name, err)
+	}
+	return duration
+}
+
+// shutdown waits for the stop signal and stops the scheduler before the
+// listener is closed, closing it makes the server stop accepting requests
+func shutdown(listener net.Listener, sched *scheduler.Scheduler, stopped chan<- bool) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	<-signals
+
+	log.Println("Shutting down the server")
+	sched.Stop()
+
+	stopped <- true
+	if err := listener.Close(); err != nil {
+		log.Println("Could not close the listener", err)
+	}
+}
+
 func main() {
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -60,16 +115,36 @@ func main() {
 		ClientID:   os.Getenv("CLIENT_ID"),
 		SecretKey:  os.Getenv("SECRET_KEY"),
 		BasePath:   os.Getenv("BASE_PATH"),
+		TokenKey:   os.Getenv("TOKEN_KEY"),
+
+		SigningSecret: os.Getenv("SIGNING_SECRET"),
+
+		SchedulerInterval: getDuration("SCHEDULER_INTERVAL", 5*time.Minute),
+		ReminderAfter:     getDuration("REMINDER_AFTER", 12*time.Hour),
+		TurnTimeout:       getDuration("TURN_TIMEOUT", 48*time.Hour),
 	}
 
 	validate := validator.New(&validator.Config{TagName: "validate"})
 
+	key, err := hex.DecodeString(config.TokenKey)
+	if err != nil {
+		log.Fatalln("Make sure the TOKEN_KEY has been set to 64 hex chars", err)
+	}
+
+	box, err := secret.NewBox(key)
+	if err != nil {
+		log.Fatalln("Make sure the TOKEN_KEY has been set to 64 hex chars", err)
+	}
+
 	db := sqlx.MustConnect("postgres", config.DBUrl)
 
 	context := server.Context{
-		Db:       db,
-		Validate: validate,
-		Config:   config,
+		Db:          db,
+		Validate:    validate,
+		Config:      config,
+		Secret:      box,
+		Signer:      secret.NewSigner(secret.DeriveKey(key, "cookies")),
+		ImageSigner: secret.NewSigner(secret.DeriveKey(key, "images")),
 	}
 	router := Router(context)
 
@@ -77,6 +152,27 @@ func main() {
 	loggedRouter := handlers.LoggingHandler(os.Stdout, recoveryRouter)
 	compressRouter := handlers.CompressHandler(loggedRouter)
 
+	sched := scheduler.New(db, scheduler.Config{
+		Interval:    config.SchedulerInterval,
+		RemindAfter: config.ReminderAfter,
+		Timeout:     config.TurnTimeout,
+	}, scheduler.SlackNotifier{Context: context})
+	sched.Start()
+
+	listener, err := net.Listen("tcp", ":"+config.Port)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stopped := make(chan bool, 1)
+	go shutdown(listener, sched, stopped)
+
 	log.Printf("Starting server on port %s\n", config.Port)
-	log.Fatal(http.ListenAndServe(":"+config.Port, compressRouter))
+	err = http.Serve(listener, compressRouter)
+
+	select {
+	case <-stopped:
+	default:
+		log.Fatal(err)
+	}
 }
@@ -1,10 +1,20 @@
 package server
 
 import (
+	"errors"
+	"log"
+	"time"
+
 	"github.com/jmoiron/sqlx"
+	"github.com/slack-games/slack-client"
+	"github.com/slack-games/slack-server/datastore"
+	"github.com/slack-games/slack-server/secret"
 	"gopkg.in/bluesuncorp/validator.v8"
 )
 
+// ErrTokenRevoked is returned when the team has revoked the app tokens
+var ErrTokenRevoked = errors.New("Team token has been revoked")
+
 // Config needed config to run the application
 type Config struct {
 	DBUrl      string
@@ -14,6 +24,14 @@ type Config struct {
 	ClientID   string
 	SecretKey  string
 	BasePath   string
+	// SigningSecret verifies the Events API requests
+	SigningSecret string
+	// TokenKey is the hex encoded key to encrypt the OAuth tokens
+	TokenKey string
+	// Turn timeouts for the scheduler
+	SchedulerInterval time.Duration
+	ReminderAfter     time.Duration
+	TurnTimeout       time.Duration
 }
 
 // Context holds reference example for database instance
@@ -21,4 +39,51 @@ type Context struct {
 	Db       *sqlx.DB
 	Validate *validator.Validate
 	Config   Config
+	Secret   *secret.Box
+	Signer   *secret.Signer
+	// ImageSigner signs the image URLs of the boards only the player sees
+	ImageSigner *secret.Signer
+}
+
+// BotToken returns the decrypted bot token of the team
+func (c Context) BotToken(teamID string) (string, error) {
+	token, err := datastore.GetTeamToken(c.Db, teamID)
+	if err != nil {
+		return "", err
+	}
+
+	if token.Revoked.Valid || token.BotToken == nil {
+		return "", ErrTokenRevoked
+	}
+
+	value, err := c.Secret.Open(token.BotToken)
+	return string(value), err
+}
+
+// SlackClient returns the Web API client to post on behalf of the team, the
+// failed calls go through HandleAPIError
+func (c Context) SlackClient(teamID string) (*slack.Client, error) {
+	token, err := c.BotToken(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := slack.NewClient(token)
+	client.OnError = func(err error) {
+		c.HandleAPIError(teamID, err)
+	}
+	return client, nil
+}
+
+// HandleAPIError revokes the team tokens when Slack does not accept them
+// anymore
+func (c Context) HandleAPIError(teamID string, err error) {
+	if !slack.IsTokenRevoked(err) {
+		return
+	}
+
+	log.Println("Team token revoked", teamID, err)
+	if err = datastore.RevokeTeamToken(c.Db, teamID); err != nil {
+		log.Println("Could not revoke the team token", teamID, err)
+	}
 }